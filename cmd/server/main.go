@@ -1,44 +1,227 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"log"
 	"net"
+	"net/http"
+	"time"
 
 	"github.com/aungmyozaw92/go-grpc-starter/config"
-	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
 	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
 	grpcHandler "github.com/aungmyozaw92/go-grpc-starter/internal/interface/grpc"
+	httpHandler "github.com/aungmyozaw92/go-grpc-starter/internal/interface/http"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/repository"
 	"github.com/aungmyozaw92/go-grpc-starter/internal/usecase"
 	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
 	"google.golang.org/grpc"
 )
 
 func main() {
+	storage := flag.String("storage", "database", `where users are kept: "database", or "memory" for tests and demos`)
+	flag.Parse()
+	args := flag.Args()
+
 	// Load configuration
 	cfg := config.Load()
 
+	switch *storage {
+	case "database":
+	case "memory":
+		if len(args) > 0 {
+			log.Fatal("There is no database to migrate with --storage=memory")
+		}
+		// Only users have an in-memory repository. The rest goes to an
+		// SQLite database in memory, so that none of it outlives the users
+		// it refers to.
+		cfg.Database = config.DatabaseConfig{Driver: "sqlite", Name: ":memory:", MigrateOnStart: true}
+	default:
+		log.Fatalf("Unknown storage %q, expected database or memory", *storage)
+	}
+
 	// Connect to database
 	db, err := cfg.ConnectDatabase()
 	if err != nil {
 		log.Fatal("Database connection failed:", err)
 	}
+	if *storage == "memory" {
+		// Every connection to :memory: opens a database of its own
+		sqlDB, err := db.DB()
+		if err != nil {
+			log.Fatal("Database connection failed:", err)
+		}
+		sqlDB.SetMaxOpenConns(1)
+	}
+
+	migrator, err := infrastructure.NewMigrator(db)
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+	if len(args) > 0 && args[0] == "migrate" {
+		runMigrate(migrator, args[1:])
+		return
+	}
+	if len(args) > 0 {
+		log.Fatalf("Unknown command %q, did you mean migrate?", args[0])
+	}
+
+	if cfg.Database.MigrateOnStart {
+		applied, err := migrator.Up(0)
+		if err != nil {
+			log.Fatal("Failed to migrate database:", err)
+		}
+		for _, m := range applied {
+			log.Printf("Applied migration %d %s", m.Version, m.Name)
+		}
+	}
+	// Running against a schema the code wasn't written for corrupts data
+	if err := migrator.Check(); err != nil {
+		if errors.Is(err, infrastructure.ErrSchemaOutdated) {
+			log.Fatalf("Refusing to start: %v; run \"server migrate up\" first", err)
+		}
+		log.Fatalf("Refusing to start: %v", err)
+	}
+
+	var repo repository.UserRepository
+	switch *storage {
+	case "memory":
+		repo = infrastructure.NewMemoryUserRepository()
+	case "database":
+		repo = infrastructure.NewUserRepository(db)
+	}
+	roleRepo := infrastructure.NewRoleRepository(db)
+	roleUC := usecase.NewRoleUseCase(roleRepo, repo)
+	if err := roleUC.EnsureDefaults(); err != nil {
+		log.Fatal("Failed to seed roles:", err)
+	}
+
+	refreshTokenRepo := infrastructure.NewRefreshTokenRepository(db)
+	var revocationRepo repository.TokenRevocationRepository
+	switch cfg.JWT.RevocationStore {
+	case "memory":
+		revocationRepo = infrastructure.NewMemoryTokenRevocationRepository()
+	case "database":
+		revocationRepo = infrastructure.NewTokenRevocationRepository(db)
+	default:
+		log.Fatalf("Unknown token revocation store: %s", cfg.JWT.RevocationStore)
+	}
+	if err := infrastructure.StartRevocationCleanup(context.Background(), revocationRepo, cfg.JWT.RevocationCleanupInterval); err != nil {
+		log.Fatal("Failed to configure token revocation:", err)
+	}
+
+	jwtKeys, err := infrastructure.LoadJWTKeySet(cfg.JWT)
+	if err != nil {
+		log.Fatal("Failed to load JWT keys:", err)
+	}
+	jwtManager := infrastructure.NewJWTManager(jwtKeys, cfg.JWT.AccessTokenTTL, revocationRepo)
+	loginThrottleRepo := infrastructure.NewLoginThrottleRepository(db)
+	// The store also counts password reset requests, keep those long enough
+	throttleWindow := max(cfg.Login.FailureWindow, cfg.Reset.RateWindow)
+	if err := infrastructure.StartLoginThrottleCleanup(context.Background(), loginThrottleRepo, throttleWindow, cfg.Login.CleanupInterval); err != nil {
+		log.Fatal("Failed to configure login throttling:", err)
+	}
+	loginGuard := usecase.NewLoginGuard(loginThrottleRepo, cfg.Login)
+	passwordHasher, err := infrastructure.NewPasswordHasher(cfg.Password)
+	if err != nil {
+		log.Fatal("Failed to configure password hashing:", err)
+	}
+	hashingPool, err := infrastructure.NewHashingPool(passwordHasher, cfg.Password.Workers, cfg.Password.QueueSize)
+	if err != nil {
+		log.Fatal("Failed to configure password hashing:", err)
+	}
+	passwordPolicy, err := usecase.NewPasswordPolicy(cfg.Policy, infrastructure.NewPasswordHistoryRepository(db), hashingPool)
+	if err != nil {
+		log.Fatal("Failed to configure password policy:", err)
+	}
 
-	// Auto-migrate the schema
-	err = db.AutoMigrate(&entity.User{})
+	mailer, err := infrastructure.NewMailer(cfg.Mail)
+	if err != nil {
+		log.Fatal("Failed to configure mailer:", err)
+	}
+	oneTimeTokenRepo := infrastructure.NewOneTimeTokenRepository(db)
+	verifyUC, err := usecase.NewEmailVerificationUseCase(repo, oneTimeTokenRepo, mailer, cfg.Verify)
 	if err != nil {
-		log.Fatal("Failed to migrate database:", err)
+		log.Fatal("Failed to configure email verification:", err)
+	}
+	mfaUC, err := usecase.NewMFAUseCase(infrastructure.NewMFARepository(db), repo, oneTimeTokenRepo, cfg.MFA)
+	if err != nil {
+		log.Fatal("Failed to configure two-factor authentication:", err)
+	}
+
+	apiKeyUC := usecase.NewAPIKeyUseCase(infrastructure.NewAPIKeyRepository(db), repo, roleRepo, verifyUC)
+	resetUC, err := usecase.NewPasswordResetUseCase(repo, oneTimeTokenRepo, refreshTokenRepo, loginThrottleRepo, jwtManager, loginGuard, mailer, hashingPool, passwordPolicy, apiKeyUC, cfg.Reset)
+	if err != nil {
+		log.Fatal("Failed to configure password reset:", err)
+	}
+	sessionUC := usecase.NewSessionUseCase(infrastructure.NewSessionRepository(db), refreshTokenRepo)
+
+	oauthUC := usecase.NewOAuthUseCase(infrastructure.NewOAuthRepository(db), repo, refreshTokenRepo, sessionUC, verifyUC, jwtManager, cfg.JWT.RefreshTokenTTL, cfg.OAuth)
+
+	uc := usecase.NewUserUseCase(repo, roleRepo, refreshTokenRepo, jwtManager, cfg.JWT.RefreshTokenTTL, loginGuard, verifyUC, mfaUC, hashingPool, passwordPolicy, apiKeyUC, sessionUC)
+
+	if cfg.RBAC.BootstrapAdmin != "" {
+		switch {
+		case cfg.RBAC.BootstrapAdminPassword != "":
+			if err := uc.CreateBootstrapUser(context.Background(), cfg.RBAC.BootstrapAdmin, cfg.RBAC.BootstrapAdminPassword); err != nil {
+				log.Fatal("Failed to create bootstrap admin:", err)
+			}
+		case *storage == "memory":
+			// Nobody registered before this start, so it would never succeed
+			log.Fatal("Users kept in memory start out empty, set RBAC_BOOTSTRAP_ADMIN_PASSWORD to create the bootstrap admin")
+		}
+		if err := roleUC.BootstrapAdmin(context.Background(), cfg.RBAC.BootstrapAdmin); err != nil {
+			log.Fatal("Failed to bootstrap admin:", err)
+		}
 	}
 
-	repo := infrastructure.NewUserRepository(db)
-	uc := usecase.NewUserUseCase(repo)
-	handler := grpcHandler.NewUserHandler(uc)
+	oidcProviders := make(map[string]*infrastructure.OIDCProvider, len(cfg.OIDC.Providers))
+	for name, providerCfg := range cfg.OIDC.Providers {
+		provider, err := infrastructure.NewOIDCProvider(providerCfg, cfg.OIDC.HTTPTimeout)
+		if err != nil {
+			log.Fatalf("Failed to configure OIDC provider %s: %v", name, err)
+		}
+		oidcProviders[name] = provider
+	}
+	oidcLoginUC := usecase.NewOIDCLoginUseCase(oidcProviders, infrastructure.NewExternalIdentityRepository(db), repo, uc)
+
+	handler := grpcHandler.NewUserHandler(uc, roleUC, resetUC, verifyUC, mfaUC, apiKeyUC, sessionUC, oauthUC, oidcLoginUC)
 
 	lis, err := net.Listen("tcp", cfg.Server.Port)
 	if err != nil {
 		log.Fatalf("Failed to listen: %v", err)
 	}
 
-	grpcServer := grpc.NewServer()
+	var oauthHandler *httpHandler.OAuthHandler
+	if cfg.OAuth.Issuer != "" {
+		if cfg.Server.HTTPPort == "" {
+			log.Fatal("The OAuth provider is served over HTTP, set SERVER_HTTP_PORT")
+		}
+		// Clients verify ID tokens with the published keys
+		if !jwtKeys.Asymmetric() {
+			log.Fatal("The OAuth provider needs an asymmetric JWT signing key")
+		}
+		oauthHandler = httpHandler.NewOAuthHandler(oauthUC, uc, jwtKeys)
+	}
+
+	if cfg.Server.HTTPPort != "" {
+		go serveHTTP("HTTP server", cfg.Server.HTTPPort, httpHandler.NewRouter(jwtKeys, oauthHandler))
+	}
+	if cfg.Server.MetricsPort != "" {
+		go serveHTTP("Metrics server", cfg.Server.MetricsPort, httpHandler.NewMetricsRouter())
+	}
+
+	deadlineInterceptor, err := grpcHandler.NewDeadlineInterceptor(cfg.Server.RPCTimeout, cfg.Server.RPCTimeouts)
+	if err != nil {
+		log.Fatal("Failed to configure RPC timeouts:", err)
+	}
+	authInterceptor := grpcHandler.NewAuthInterceptor(uc, grpcHandler.PublicMethods)
+	grpcServer := grpc.NewServer(
+		// Authentication queries the database too, so it runs under the deadline
+		grpc.ChainUnaryInterceptor(deadlineInterceptor.Unary(), authInterceptor.Unary()),
+		grpc.StreamInterceptor(authInterceptor.Stream()),
+	)
 	userpb.RegisterUserServiceServer(grpcServer, handler)
 
 	log.Printf("gRPC server running on %s", cfg.Server.Port)
@@ -46,3 +229,16 @@ func main() {
 		log.Fatalf("Failed to serve: %v", err)
 	}
 }
+
+// serveHTTP serves handler on addr until the listener fails.
+func serveHTTP(name, addr string, handler http.Handler) {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log.Printf("%s running on %s", name, addr)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		log.Fatalf("Failed to serve %s: %v", name, err)
+	}
+}
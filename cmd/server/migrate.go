@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
+)
+
+const migrateUsage = `usage: server migrate <command>
+
+commands:
+  up [N]      apply the next N pending migrations, all of them by default
+  down [N]    revert the last N applied migrations, 1 by default
+  status      list the migrations and whether they are applied`
+
+// runMigrate handles "server migrate ...", with args being what follows
+// "migrate".
+func runMigrate(migrator *infrastructure.Migrator, args []string) {
+	if len(args) == 0 || len(args) > 2 {
+		exitUsage()
+	}
+	steps := 0
+	if args[0] == "down" {
+		steps = 1
+	}
+	if len(args) == 2 {
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			exitUsage()
+		}
+		steps = n
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := migrator.Up(steps)
+		for _, m := range applied {
+			log.Printf("Applied migration %d %s", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal("Migration failed: ", err)
+		}
+		if len(applied) == 0 {
+			log.Printf("Schema is up to date at version %d", migrator.Latest())
+		}
+	case "down":
+		reverted, err := migrator.Down(steps)
+		for _, m := range reverted {
+			log.Printf("Reverted migration %d %s", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatal("Migration failed: ", err)
+		}
+		if len(reverted) == 0 {
+			log.Println("No migrations to revert")
+		}
+	case "status":
+		if len(args) != 1 {
+			exitUsage()
+		}
+		statuses, err := migrator.Status()
+		if err != nil {
+			log.Fatal("Failed to read migration status: ", err)
+		}
+		for _, s := range statuses {
+			state := "pending"
+			if s.AppliedAt != nil {
+				state = "applied " + s.AppliedAt.Format(time.RFC3339)
+			}
+			if s.Unknown {
+				state += " (unknown to this build)"
+			}
+			fmt.Printf("%04d %-40s %s\n", s.Version, s.Name, state)
+		}
+	default:
+		exitUsage()
+	}
+}
+
+func exitUsage() {
+	fmt.Fprintln(os.Stderr, migrateUsage)
+	os.Exit(2)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 )
 
 func main() {
@@ -58,14 +59,16 @@ func main() {
 		log.Fatalf("Login failed: %s", loginResp.Message)
 	}
 
-	token := loginResp.Token
+	token := loginResp.AccessToken
 	fmt.Printf("✅ Authentication successful\n")
 	fmt.Printf("Token: %s...\n\n", token[:20])
 
+	// Authenticate the remaining calls via the authorization header
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+
 	// Step 2: Create a new user
 	fmt.Println("=== Step 2: Create User ===")
 	createReq := &userpb.CreateUserRequest{
-		Token:    token,
 		Username: "newuser123",
 		Name:     "New User",
 		Email:    "newuser@example.com",
@@ -96,7 +99,6 @@ func main() {
 
 	fmt.Printf("=== Step 3: Get User (ID: %d) ===\n", userID)
 	getUserReq := &userpb.GetUserRequest{
-		Token:  token,
 		UserId: userID,
 	}
 
@@ -121,7 +123,6 @@ func main() {
 	// Step 4: Update user
 	fmt.Printf("=== Step 4: Update User (ID: %d) ===\n", userID)
 	updateReq := &userpb.UpdateUserRequest{
-		Token:    token,
 		UserId:   userID,
 		Username: "updateduser123",
 		Name:     "Updated User Name",
@@ -130,7 +131,7 @@ func main() {
 		Mobile:   "555-2002",
 		ImageUrl: "https://example.com/updated.jpg",
 		IsActive: true,
-		RoleId:   3,
+		RoleId:   0, // unchanged, only the built-in roles are seeded
 	}
 
 	updateResp, err := client.UpdateUser(ctx, updateReq)
@@ -144,13 +145,12 @@ func main() {
 		fmt.Printf("Name: %s (updated)\n", user.Name)
 		fmt.Printf("Email: %s (updated)\n", user.Email)
 		fmt.Printf("Phone: %s (updated)\n", user.Phone)
-		fmt.Printf("Role ID: %d (updated)\n\n", user.RoleId)
+		fmt.Printf("Role ID: %d\n\n", user.RoleId)
 	}
 
 	// Step 5: Get user list to see all users
 	fmt.Println("=== Step 5: Get User List ===")
 	userListReq := &userpb.UserListRequest{
-		Token: token,
 		Page:  1,
 		Limit: 10,
 	}
@@ -171,7 +171,6 @@ func main() {
 	// Step 6: Create another user to test deletion
 	fmt.Println("=== Step 6: Create User for Deletion Test ===")
 	createDeleteReq := &userpb.CreateUserRequest{
-		Token:    token,
 		Username: "deleteme123",
 		Name:     "Delete Me User",
 		Email:    "deleteme@example.com",
@@ -196,7 +195,6 @@ func main() {
 	// Step 7: Delete user
 	fmt.Printf("=== Step 7: Delete User (ID: %d) ===\n", deleteUserID)
 	deleteReq := &userpb.DeleteUserRequest{
-		Token:  token,
 		UserId: deleteUserID,
 	}
 
@@ -211,7 +209,6 @@ func main() {
 	// Step 8: Try to get the deleted user (should fail)
 	fmt.Printf("=== Step 8: Verify Deletion (Get Deleted User) ===\n")
 	getDeletedReq := &userpb.GetUserRequest{
-		Token:  token,
 		UserId: deleteUserID,
 	}
 
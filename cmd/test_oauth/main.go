@@ -0,0 +1,372 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/config"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
+	httpHandler "github.com/aungmyozaw92/go-grpc-starter/internal/interface/http"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/usecase"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Drives the OAuth 2.0 / OpenID Connect flows against the HTTP endpoints
+// served in-process, backed by the configured database.
+func main() {
+	cfg := config.Load()
+	db, err := cfg.ConnectDatabase()
+	if err != nil {
+		log.Fatal("Database connection failed:", err)
+	}
+	migrator, err := infrastructure.NewMigrator(db)
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+	if err := migrator.Check(); err != nil {
+		log.Fatalf("%v; run \"go run ./cmd/server migrate up\" first", err)
+	}
+
+	// The provider is served on an ephemeral port; its URL is the issuer
+	server := httptest.NewServer(nil)
+	defer server.Close()
+	cfg.OAuth.Issuer = server.URL
+
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		log.Fatal(err)
+	}
+	keys, err := infrastructure.NewJWTKeySet(&infrastructure.JWTKey{ID: "test-oauth", Method: jwt.SigningMethodEdDSA, PrivateKey: privateKey, PublicKey: publicKey})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	repo := infrastructure.NewUserRepository(db)
+	roleRepo := infrastructure.NewRoleRepository(db)
+	if err := usecase.NewRoleUseCase(roleRepo, repo).EnsureDefaults(); err != nil {
+		log.Fatal("Failed to seed roles:", err)
+	}
+	refreshTokenRepo := infrastructure.NewRefreshTokenRepository(db)
+	jwtManager := infrastructure.NewJWTManager(keys, cfg.JWT.AccessTokenTTL, infrastructure.NewMemoryTokenRevocationRepository())
+	loginGuard := usecase.NewLoginGuard(infrastructure.NewLoginThrottleRepository(db), cfg.Login)
+	passwordHasher, err := infrastructure.NewPasswordHasher(cfg.Password)
+	if err != nil {
+		log.Fatal(err)
+	}
+	hashingPool, err := infrastructure.NewHashingPool(passwordHasher, cfg.Password.Workers, cfg.Password.QueueSize)
+	if err != nil {
+		log.Fatal(err)
+	}
+	passwordPolicy, err := usecase.NewPasswordPolicy(cfg.Policy, infrastructure.NewPasswordHistoryRepository(db), hashingPool)
+	if err != nil {
+		log.Fatal(err)
+	}
+	mailer, err := infrastructure.NewMailer(config.MailConfig{Driver: "log"})
+	if err != nil {
+		log.Fatal(err)
+	}
+	oneTimeTokenRepo := infrastructure.NewOneTimeTokenRepository(db)
+	verifyUC, err := usecase.NewEmailVerificationUseCase(repo, oneTimeTokenRepo, mailer, config.EmailVerificationConfig{Mode: "off", TokenTTL: time.Hour})
+	if err != nil {
+		log.Fatal(err)
+	}
+	mfaUC, err := usecase.NewMFAUseCase(infrastructure.NewMFARepository(db), repo, oneTimeTokenRepo, cfg.MFA)
+	if err != nil {
+		log.Fatal(err)
+	}
+	apiKeyUC := usecase.NewAPIKeyUseCase(infrastructure.NewAPIKeyRepository(db), repo, roleRepo, verifyUC)
+	sessionUC := usecase.NewSessionUseCase(infrastructure.NewSessionRepository(db), refreshTokenRepo)
+	oauthUC := usecase.NewOAuthUseCase(infrastructure.NewOAuthRepository(db), repo, refreshTokenRepo, sessionUC, verifyUC, jwtManager, cfg.JWT.RefreshTokenTTL, cfg.OAuth)
+	uc := usecase.NewUserUseCase(repo, roleRepo, refreshTokenRepo, jwtManager, cfg.JWT.RefreshTokenTTL, loginGuard, verifyUC, mfaUC, hashingPool, passwordPolicy, apiKeyUC, sessionUC)
+	server.Config.Handler = httpHandler.NewRouter(keys, httpHandler.NewOAuthHandler(oauthUC, uc, keys))
+
+	ctx := context.Background()
+	// Don't follow redirects, the client's redirect URI is not served
+	browser := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+
+	fmt.Println("🧪 Testing the OAuth 2.0 / OpenID Connect provider")
+	fmt.Println("==================================================")
+
+	fmt.Println("\n=== Step 1: Sign in a user ===")
+	suffix := fmt.Sprint(time.Now().UnixNano() % 1_000_000_000)
+	username := "oauth" + suffix
+	email := username + "@example.com"
+	password := "Correct-Horse-" + suffix
+	if _, err := uc.Register(ctx, &entity.User{Username: username, Name: "OAuth Tester", Email: &email, Password: password}, usecase.ClientInfo{}); err != nil {
+		log.Fatalf("Failed to register: %v", err)
+	}
+	login, _, err := uc.Login(ctx, username, password, usecase.ClientInfo{})
+	if err != nil {
+		log.Fatalf("Failed to log in: %v", err)
+	}
+	fmt.Printf("✅ Signed in as %s\n", username)
+
+	fmt.Println("\n=== Step 2: Register OAuth clients ===")
+	adminCtx := usecase.WithPrincipal(ctx, &usecase.Principal{Permissions: map[string]bool{entity.PermissionOAuthClientsManage: true}})
+	redirectURI := "http://127.0.0.1:8765/callback"
+	webApp, _, err := oauthUC.CreateClient(adminCtx, usecase.OAuthClientRegistration{
+		Name:         "Web app",
+		RedirectURIs: []string{redirectURI},
+		GrantTypes:   []string{entity.OAuthGrantAuthorizationCode, entity.OAuthGrantRefreshToken},
+		Scopes:       []string{entity.OAuthScopeOpenID, entity.OAuthScopeProfile, entity.OAuthScopeEmail},
+		Public:       true,
+	})
+	if err != nil {
+		log.Fatalf("Failed to register the web app: %v", err)
+	}
+	// Without the refresh token grant the access token is all a client gets
+	codeOnly, _, err := oauthUC.CreateClient(adminCtx, usecase.OAuthClientRegistration{
+		Name:         "Code-only app",
+		RedirectURIs: []string{redirectURI},
+		GrantTypes:   []string{entity.OAuthGrantAuthorizationCode},
+		Scopes:       []string{entity.OAuthScopeOpenID, entity.OAuthScopeProfile},
+		Public:       true,
+	})
+	if err != nil {
+		log.Fatalf("Failed to register the code-only app: %v", err)
+	}
+	worker, workerSecret, err := oauthUC.CreateClient(adminCtx, usecase.OAuthClientRegistration{
+		Name:       "Worker",
+		GrantTypes: []string{entity.OAuthGrantClientCredentials},
+		Scopes:     []string{"reports:read"},
+	})
+	if err != nil {
+		log.Fatalf("Failed to register the worker: %v", err)
+	}
+	fmt.Printf("✅ Registered public clients %s and %s, and confidential client %s\n", webApp.ClientID, codeOnly.ClientID, worker.ClientID)
+
+	fmt.Println("\n=== Step 3: Discovery ===")
+	var discovery struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		UserInfoEndpoint      string `json:"userinfo_endpoint"`
+		JWKSURI               string `json:"jwks_uri"`
+	}
+	getJSON(server.URL+httpHandler.DiscoveryPath, "", &discovery)
+	if discovery.Issuer != server.URL || discovery.TokenEndpoint != server.URL+httpHandler.TokenPath {
+		log.Fatalf("Unexpected discovery document: %+v", discovery)
+	}
+	fmt.Printf("✅ Issuer %s\n", discovery.Issuer)
+
+	fmt.Println("\n=== Step 4: Authorize with PKCE ===")
+	verifier := randomString()
+	challenge := sha256.Sum256([]byte(verifier))
+	authorize := discovery.AuthorizationEndpoint + "?" + url.Values{
+		"response_type":         {"code"},
+		"client_id":             {webApp.ClientID},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {"openid profile email"},
+		"state":                 {"xyz"},
+		"nonce":                 {"n-0S6_WzA2Mj"},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}.Encode()
+	resp := send(browser, http.MethodGet, authorize, "", nil)
+	if resp.StatusCode != http.StatusUnauthorized {
+		log.Fatalf("Expected 401 without a signed-in user, got %d", resp.StatusCode)
+	}
+	resp = send(browser, http.MethodGet, authorize, login.AccessToken, nil)
+	location, err := resp.Location()
+	if resp.StatusCode != http.StatusFound || err != nil {
+		log.Fatalf("Expected a redirect, got %d", resp.StatusCode)
+	}
+	code := location.Query().Get("code")
+	if code == "" || location.Query().Get("state") != "xyz" {
+		log.Fatalf("Unexpected redirect %s", location)
+	}
+	fmt.Printf("✅ Redirected to %s://%s%s with a code\n", location.Scheme, location.Host, location.Path)
+
+	fmt.Println("\n=== Step 5: Exchange the code ===")
+	exchange := url.Values{
+		"grant_type":    {entity.OAuthGrantAuthorizationCode},
+		"client_id":     {webApp.ClientID},
+		"code":          {code},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {"wrong" + verifier[5:]},
+	}
+	if status, body := postForm(discovery.TokenEndpoint, exchange, nil); status != http.StatusBadRequest || body["error"] != usecase.OAuthInvalidGrant {
+		log.Fatalf("Expected invalid_grant for a wrong verifier, got %d %v", status, body)
+	}
+	exchange.Set("code_verifier", verifier)
+	status, tokens := postForm(discovery.TokenEndpoint, exchange, nil)
+	if status != http.StatusOK || tokens["id_token"] == nil || tokens["refresh_token"] == nil {
+		log.Fatalf("Token request failed: %d %v", status, tokens)
+	}
+	fmt.Println("✅ Got access, refresh and ID tokens")
+
+	fmt.Println("\n=== Step 6: Verify the ID token with the JWKS ===")
+	var jwks infrastructure.JWKS
+	getJSON(discovery.JWKSURI, "", &jwks)
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(tokens["id_token"].(string), claims, func(token *jwt.Token) (interface{}, error) {
+		for _, key := range jwks.Keys {
+			if key.Kid == token.Header["kid"] && key.Kty == "OKP" {
+				x, err := base64.RawURLEncoding.DecodeString(key.X)
+				return ed25519.PublicKey(x), err
+			}
+		}
+		return nil, fmt.Errorf("unknown key %v", token.Header["kid"])
+	}, jwt.WithIssuer(server.URL), jwt.WithAudience(webApp.ClientID), jwt.WithExpirationRequired())
+	if err != nil {
+		log.Fatalf("ID token did not verify: %v", err)
+	}
+	if claims["nonce"] != "n-0S6_WzA2Mj" || claims["preferred_username"] != username || claims["email"] != email {
+		log.Fatalf("Unexpected ID token claims: %v", claims)
+	}
+	fmt.Printf("✅ ID token for subject %v verified\n", claims["sub"])
+
+	fmt.Println("\n=== Step 7: UserInfo ===")
+	var info map[string]interface{}
+	getJSON(discovery.UserInfoEndpoint, tokens["access_token"].(string), &info)
+	if info["sub"] != claims["sub"] || info["preferred_username"] != username {
+		log.Fatalf("Unexpected userinfo: %v", info)
+	}
+	if _, err := uc.Authenticate(ctx, tokens["access_token"].(string)); err == nil {
+		log.Fatal("The client's access token was accepted by the API")
+	}
+	fmt.Println("✅ UserInfo matches; the API rejects the client's token")
+
+	fmt.Println("\n=== Step 8: Refresh, and replay the code ===")
+	status, refreshed := postForm(discovery.TokenEndpoint, url.Values{
+		"grant_type":    {entity.OAuthGrantRefreshToken},
+		"client_id":     {webApp.ClientID},
+		"refresh_token": {tokens["refresh_token"].(string)},
+		"scope":         {"openid"},
+	}, nil)
+	if status != http.StatusOK || refreshed["scope"] != "openid" {
+		log.Fatalf("Refresh failed: %d %v", status, refreshed)
+	}
+	if _, err := uc.RefreshToken(ctx, refreshed["refresh_token"].(string)); err == nil {
+		log.Fatal("The client's refresh token was accepted by the API")
+	}
+	if status, body := postForm(discovery.TokenEndpoint, exchange, nil); status != http.StatusBadRequest || body["error"] != usecase.OAuthInvalidGrant {
+		log.Fatalf("Expected invalid_grant for a replayed code, got %d %v", status, body)
+	}
+	resp = send(http.DefaultClient, http.MethodGet, discovery.UserInfoEndpoint, refreshed["access_token"].(string), nil)
+	if resp.StatusCode != http.StatusUnauthorized {
+		log.Fatalf("Expected the session to end after the replay, got %d", resp.StatusCode)
+	}
+	fmt.Println("✅ Refreshed with a narrower scope; the replay ended the session")
+
+	fmt.Println("\n=== Step 9: Client credentials ===")
+	credentials := url.Values{"grant_type": {entity.OAuthGrantClientCredentials}}
+	if status, _ := postForm(discovery.TokenEndpoint, credentials, &[2]string{worker.ClientID, "wrong"}); status != http.StatusUnauthorized {
+		log.Fatalf("Expected 401 for a wrong secret, got %d", status)
+	}
+	status, machine := postForm(discovery.TokenEndpoint, credentials, &[2]string{worker.ClientID, workerSecret})
+	if status != http.StatusOK || machine["scope"] != "reports:read" || machine["refresh_token"] != nil {
+		log.Fatalf("Client credentials failed: %d %v", status, machine)
+	}
+	fmt.Println("✅ Worker got an access token for itself")
+
+	fmt.Println("\n=== Step 10: Authorization code without refresh tokens ===")
+	verifier = randomString()
+	challenge = sha256.Sum256([]byte(verifier))
+	resp = send(browser, http.MethodGet, discovery.AuthorizationEndpoint+"?"+url.Values{
+		"response_type":         {"code"},
+		"client_id":             {codeOnly.ClientID},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {"openid profile"},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}.Encode(), login.AccessToken, nil)
+	location, err = resp.Location()
+	if resp.StatusCode != http.StatusFound || err != nil || location.Query().Get("code") == "" {
+		log.Fatalf("Expected a redirect with a code, got %d", resp.StatusCode)
+	}
+	status, codeOnlyTokens := postForm(discovery.TokenEndpoint, url.Values{
+		"grant_type":    {entity.OAuthGrantAuthorizationCode},
+		"client_id":     {codeOnly.ClientID},
+		"code":          {location.Query().Get("code")},
+		"redirect_uri":  {redirectURI},
+		"code_verifier": {verifier},
+	}, nil)
+	if status != http.StatusOK || codeOnlyTokens["access_token"] == nil || codeOnlyTokens["refresh_token"] != nil {
+		log.Fatalf("Token request failed: %d %v", status, codeOnlyTokens)
+	}
+	info = nil
+	getJSON(discovery.UserInfoEndpoint, codeOnlyTokens["access_token"].(string), &info)
+	if info["preferred_username"] != username {
+		log.Fatalf("Unexpected userinfo: %v", info)
+	}
+	fmt.Println("✅ Got an access token without a refresh token, and it works for UserInfo")
+
+	fmt.Println("\n🎉 OAuth flows passed")
+}
+
+func send(client *http.Client, method, target, bearer string, body url.Values) *http.Response {
+	var req *http.Request
+	var err error
+	if body != nil {
+		req, err = http.NewRequest(method, target, strings.NewReader(body.Encode()))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+	} else {
+		req, err = http.NewRequest(method, target, nil)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Fatalf("%s %s failed: %v", method, target, err)
+	}
+	return resp
+}
+
+func getJSON(target, bearer string, v interface{}) {
+	resp := send(http.DefaultClient, http.MethodGet, target, bearer, nil)
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		log.Fatalf("GET %s: %s", target, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		log.Fatalf("GET %s: %v", target, err)
+	}
+}
+
+// postForm posts to the token endpoint, authenticating with basic when
+// basic holds a client ID and secret.
+func postForm(target string, form url.Values, basic *[2]string) (int, map[string]interface{}) {
+	req, err := http.NewRequest(http.MethodPost, target, strings.NewReader(form.Encode()))
+	if err != nil {
+		log.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if basic != nil {
+		req.SetBasicAuth(url.QueryEscape(basic[0]), url.QueryEscape(basic[1]))
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		log.Fatalf("POST %s failed: %v", target, err)
+	}
+	defer resp.Body.Close()
+	body := map[string]interface{}{}
+	json.NewDecoder(resp.Body).Decode(&body)
+	return resp.StatusCode, body
+}
+
+func randomString() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
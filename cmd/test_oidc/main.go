@@ -0,0 +1,256 @@
+package main
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
+	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// The server under test has to know the mock issuer, e.g.:
+//
+//	OIDC_PROVIDERS=mock
+//	OIDC_MOCK_ISSUER=http://127.0.0.1:9998
+//	OIDC_MOCK_CLIENT_ID=test-client
+//	OIDC_MOCK_CLIENT_SECRET=test-secret
+//	OIDC_MOCK_REDIRECT_URI=http://localhost:3000/callback
+//
+// OIDC_MOCK_TRUST_EMAIL must stay unset, as the mock issuer is not trusted
+// to verify addresses.
+const (
+	issuerAddr   = "127.0.0.1:9998"
+	clientID     = "test-client"
+	clientSecret = "test-secret"
+	redirectURI  = "http://localhost:3000/callback"
+)
+
+// mockIssuer is a minimal OpenID Connect provider. authorize stands in for
+// the user signing in at the provider and returns the code it would
+// redirect back with.
+type mockIssuer struct {
+	url        string
+	keys       *infrastructure.JWTKeySet
+	privateKey ed25519.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]pendingCode
+}
+
+type pendingCode struct {
+	claims        jwt.MapClaims
+	codeChallenge string
+}
+
+func newMockIssuer(addr string) (*mockIssuer, error) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := infrastructure.NewJWTKeySet(&infrastructure.JWTKey{ID: "mock-1", Method: jwt.SigningMethodEdDSA, PrivateKey: privateKey, PublicKey: publicKey})
+	if err != nil {
+		return nil, err
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &mockIssuer{url: "http://" + addr, keys: keys, privateKey: privateKey, codes: make(map[string]pendingCode)}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{
+			"issuer":                 m.url,
+			"authorization_endpoint": m.url + "/authorize",
+			"token_endpoint":         m.url + "/token",
+			"jwks_uri":               m.url + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, m.keys.JWKS())
+	})
+	mux.HandleFunc("/token", m.token)
+	go http.Serve(lis, mux)
+	return m, nil
+}
+
+func (m *mockIssuer) authorize(claims jwt.MapClaims, codeChallenge string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	code := randomString()
+	m.codes[code] = pendingCode{claims: claims, codeChallenge: codeChallenge}
+	return code
+}
+
+func (m *mockIssuer) token(w http.ResponseWriter, r *http.Request) {
+	id, secret, _ := r.BasicAuth()
+	id, _ = url.QueryUnescape(id)
+	secret, _ = url.QueryUnescape(secret)
+	if id != clientID || secret != clientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	m.mu.Lock()
+	pending, ok := m.codes[r.PostFormValue("code")]
+	delete(m.codes, r.PostFormValue("code"))
+	m.mu.Unlock()
+	if !ok || r.PostFormValue("redirect_uri") != redirectURI {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+	if pending.codeChallenge != "" {
+		sum := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+		if base64.RawURLEncoding.EncodeToString(sum[:]) != pending.codeChallenge {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "PKCE verification failed"})
+			return
+		}
+	}
+
+	claims := jwt.MapClaims{
+		"iss": m.url,
+		"aud": clientID,
+		"iat": time.Now().Unix(),
+		"exp": time.Now().Add(5 * time.Minute).Unix(),
+	}
+	for k, v := range pending.claims {
+		claims[k] = v
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	token.Header["kid"] = "mock-1"
+	idToken, err := token.SignedString(m.privateKey)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": randomString(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func main() {
+	issuer, err := newMockIssuer(issuerAddr)
+	if err != nil {
+		log.Fatalf("Failed to start the mock issuer: %v", err)
+	}
+
+	conn, err := grpc.NewClient("localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("Failed to connect: %v", err)
+	}
+	defer conn.Close()
+	client := userpb.NewUserServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	fmt.Println("🧪 Testing sign-in with an external OIDC provider")
+	fmt.Println("=================================================")
+	fmt.Printf("Mock issuer at %s\n", issuer.url)
+
+	suffix := fmt.Sprint(time.Now().UnixNano() % 1_000_000_000)
+	subject := "mock-" + suffix
+	email := "sso" + suffix + "@example.com"
+	profile := jwt.MapClaims{"sub": subject, "email": email, "email_verified": true, "name": "SSO User", "preferred_username": "sso." + suffix, "nonce": "n-1"}
+
+	fmt.Println("\n=== Step 1: Reject bad requests ===")
+	_, err = client.LoginWithOIDC(ctx, &userpb.LoginWithOIDCRequest{Provider: "nope", Code: "x"})
+	expectCode(err, codes.InvalidArgument, "unknown provider")
+	_, err = client.LoginWithOIDC(ctx, &userpb.LoginWithOIDCRequest{Provider: "mock", Code: "not-issued"})
+	expectCode(err, codes.Unauthenticated, "unknown code")
+
+	fmt.Println("\n=== Step 2: First sign-in creates the account ===")
+	verifier := randomString()
+	challenge := sha256.Sum256([]byte(verifier))
+	code := issuer.authorize(profile, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	resp, err := client.LoginWithOIDC(ctx, &userpb.LoginWithOIDCRequest{Provider: "mock", Code: code, CodeVerifier: verifier, Nonce: "n-1"})
+	if err != nil {
+		log.Fatalf("❌ First sign-in failed: %v", err)
+	}
+	first, err := client.GetProfile(authorized(ctx, resp.AccessToken), &userpb.ProfileRequest{})
+	if err != nil {
+		log.Fatalf("❌ GetProfile failed: %v", err)
+	}
+	if first.Data.Email != email || first.Data.Name != "SSO User" {
+		log.Fatalf("❌ Unexpected profile: %+v", first.Data)
+	}
+	// The issuer says the address is verified, but it is not trusted to
+	if first.Data.EmailVerifiedAt != "" {
+		log.Fatalf("❌ Address of an untrusted provider taken as verified: %+v", first.Data)
+	}
+	fmt.Printf("✅ Signed in as new user %s (ID %d), email not yet verified\n", first.Data.Username, first.Data.Id)
+
+	fmt.Println("\n=== Step 3: Later sign-ins find the same account ===")
+	code = issuer.authorize(profile, "")
+	resp, err = client.LoginWithOIDC(ctx, &userpb.LoginWithOIDCRequest{Provider: "mock", Code: code, Nonce: "n-1"})
+	if err != nil {
+		log.Fatalf("❌ Second sign-in failed: %v", err)
+	}
+	second, err := client.GetProfile(authorized(ctx, resp.AccessToken), &userpb.ProfileRequest{})
+	if err != nil || second.Data.Id != first.Data.Id {
+		log.Fatalf("❌ Expected user %d again: %v", first.Data.Id, err)
+	}
+	fmt.Println("✅ Same user")
+
+	fmt.Println("\n=== Step 4: Reject tampered sign-ins ===")
+	code = issuer.authorize(profile, "")
+	_, err = client.LoginWithOIDC(ctx, &userpb.LoginWithOIDCRequest{Provider: "mock", Code: code, Nonce: "other"})
+	expectCode(err, codes.Unauthenticated, "wrong nonce")
+	code = issuer.authorize(jwt.MapClaims{"sub": subject, "aud": "someone-else"}, "")
+	_, err = client.LoginWithOIDC(ctx, &userpb.LoginWithOIDCRequest{Provider: "mock", Code: code})
+	expectCode(err, codes.Unauthenticated, "other audience")
+	code = issuer.authorize(profile, base64.RawURLEncoding.EncodeToString(challenge[:]))
+	_, err = client.LoginWithOIDC(ctx, &userpb.LoginWithOIDCRequest{Provider: "mock", Code: code, CodeVerifier: randomString()})
+	expectCode(err, codes.Unauthenticated, "wrong code verifier")
+
+	fmt.Println("\n=== Step 5: An unverified link to an existing email is refused ===")
+	code = issuer.authorize(jwt.MapClaims{"sub": "other-" + suffix, "email": email, "email_verified": false}, "")
+	_, err = client.LoginWithOIDC(ctx, &userpb.LoginWithOIDCRequest{Provider: "mock", Code: code})
+	expectCode(err, codes.AlreadyExists, "email of another account")
+
+	fmt.Println("\n🎉 OIDC sign-in passed")
+}
+
+func expectCode(err error, want codes.Code, what string) {
+	if status.Code(err) != want {
+		log.Fatalf("❌ %s: expected %s, got %v", what, want, err)
+	}
+	fmt.Printf("✅ %s: %s\n", what, want)
+}
+
+func authorized(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func randomString() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatal(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
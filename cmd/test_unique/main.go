@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 )
 
 func main() {
@@ -37,7 +40,7 @@ func main() {
 		Phone:    "555-0001",
 		Mobile:   "555-0002",
 		ImageUrl: "https://example.com/admin.jpg",
-		Password: "adminpass123",
+		Password: "s3cretpass123",
 		IsActive: true,
 		RoleId:   1,
 	}
@@ -263,6 +266,51 @@ func main() {
 		fmt.Printf("❌ Unexpected success - register email constraint not working: %s\n", dupEmailResp.Message)
 	}
 
+	// Step 7: Concurrent registrations can all pass the existence check
+	// before any of them is stored; only the unique indexes can turn all but
+	// one away
+	fmt.Println("\n=== Step 7: Testing Concurrent Registrations ===")
+
+	suffix := fmt.Sprint(time.Now().UnixNano() % 1_000_000)
+	sameUsername := make([]*userpb.RegisterRequest, 20)
+	sameEmail := make([]*userpb.RegisterRequest, 20)
+	for i := range sameUsername {
+		sameUsername[i] = &userpb.RegisterRequest{
+			Username: "racer" + suffix,
+			Name:     "Racer",
+			Email:    fmt.Sprintf("racer%d_%s@unique.com", i, suffix),
+			Password: "s3cretpass123",
+		}
+		sameEmail[i] = &userpb.RegisterRequest{
+			Username: fmt.Sprintf("racer%d_%s", i, suffix),
+			Name:     "Racer",
+			Email:    "race" + suffix + "@unique.com",
+			Password: "s3cretpass123",
+		}
+	}
+	for _, race := range []struct {
+		what     string
+		requests []*userpb.RegisterRequest
+	}{
+		{"username", sameUsername},
+		{"email", sameEmail},
+	} {
+		results := registerConcurrently(ctx, client, race.requests)
+		// Registrations the server was too busy to hash for are retried by
+		// real clients and prove nothing either way, but most of the race
+		// has to be turned away as duplicates for it to prove anything
+		minRejected := len(race.requests) / 2
+		if results[codes.OK] != 1 || results[codes.OK]+results[codes.AlreadyExists]+results[codes.ResourceExhausted] != len(race.requests) {
+			log.Fatalf("❌ Same %s: unexpected results %v", race.what, results)
+		}
+		if results[codes.AlreadyExists] < minRejected {
+			log.Fatalf("❌ Same %s: only %d of %d rejected as duplicates, %d busy; lower the load or raise PASSWORD_HASH_QUEUE_SIZE",
+				race.what, results[codes.AlreadyExists], len(race.requests), results[codes.ResourceExhausted])
+		}
+		fmt.Printf("✅ Same %s: 1 registered, %d already exists, %d busy\n",
+			race.what, results[codes.AlreadyExists], results[codes.ResourceExhausted])
+	}
+
 	fmt.Println("\n🎉 Unique Constraint Testing Completed!")
 	fmt.Println("\nSummary:")
 	fmt.Println("- Username uniqueness: Enforced ✅")
@@ -270,4 +318,28 @@ func main() {
 	fmt.Println("- Create operations: Protected ✅")
 	fmt.Println("- Update operations: Protected ✅")
 	fmt.Println("- Register operations: Protected ✅")
+	fmt.Println("- Concurrent registrations: Protected ✅")
+}
+
+// registerConcurrently sends the requests all at once and counts the
+// resulting status codes.
+func registerConcurrently(ctx context.Context, client userpb.UserServiceClient, requests []*userpb.RegisterRequest) map[codes.Code]int {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	results := make(map[codes.Code]int)
+	start := make(chan struct{})
+	for _, req := range requests {
+		wg.Add(1)
+		go func(req *userpb.RegisterRequest) {
+			defer wg.Done()
+			<-start
+			_, err := client.Register(ctx, req)
+			mu.Lock()
+			defer mu.Unlock()
+			results[status.Code(err)]++
+		}(req)
+	}
+	close(start)
+	wg.Wait()
+	return results
 }
@@ -8,7 +8,10 @@ import (
 
 	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func main() {
@@ -23,7 +26,19 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// First, register multiple users for testing
+	// Sign in as the bootstrap admin; listing users takes users:read
+	fmt.Println("=== Signing In ===")
+	loginResp, err := client.Login(ctx, &userpb.LoginRequest{
+		Identifier: "testuser",
+		Password:   "password123",
+	})
+	if err != nil {
+		log.Fatalf("Failed to sign in as testuser, the bootstrap admin (see RBAC_BOOTSTRAP_ADMIN): %v", err)
+	}
+	token := loginResp.AccessToken
+	fmt.Printf("✅ Signed in as testuser\n\n")
+
+	// Register the users to list, unless an earlier run already did
 	fmt.Println("=== Creating Test Users ===")
 
 	users := []struct {
@@ -38,9 +53,6 @@ func main() {
 		{"charlie_davis", "Charlie Davis", "charlie@example.com"},
 	}
 
-	var token string
-
-	// Register users
 	for i, user := range users {
 		registerReq := &userpb.RegisterRequest{
 			Username: user.username,
@@ -50,29 +62,22 @@ func main() {
 			Mobile:   fmt.Sprintf("555-100%d", i+1),
 			ImageUrl: "https://example.com/avatar.jpg",
 			Password: "password123",
-			IsActive: true,
-			RoleId:   1,
 		}
 
-		registerResp, err := client.Register(ctx, registerReq)
-		if err != nil {
-			fmt.Printf("Failed to register %s: %v\n", user.username, err)
-			continue
+		_, err := client.Register(ctx, registerReq)
+		switch {
+		case err == nil:
+			fmt.Printf("✅ Registered: %s\n", user.username)
+		case status.Code(err) == codes.AlreadyExists:
+			fmt.Printf("✅ Already registered: %s\n", user.username)
+		default:
+			log.Fatalf("Failed to register %s: %v", user.username, err)
 		}
-
-		fmt.Printf("✅ Registered: %s\n", user.username)
-
-		// Save token from first user for testing
-		if i == 0 {
-			token = registerResp.Token
-		}
-	}
-
-	if token == "" {
-		log.Fatal("Failed to get a valid token for testing")
 	}
+	fmt.Println()
 
-	fmt.Printf("\nUsing token: %s...\n\n", token[:20])
+	// Authenticate the list calls via the authorization header
+	authCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
 
 	// Test GetUserList with different scenarios
 	testScenarios := []struct {
@@ -95,13 +100,12 @@ func main() {
 		fmt.Printf("=== %s ===\n", scenario.name)
 
 		userListReq := &userpb.UserListRequest{
-			Token:  token,
 			Page:   scenario.page,
 			Limit:  scenario.limit,
 			Search: scenario.search,
 		}
 
-		userListResp, err := client.GetUserList(ctx, userListReq)
+		userListResp, err := client.GetUserList(authCtx, userListReq)
 		if err != nil {
 			fmt.Printf("❌ Error: %v\n\n", err)
 			continue
@@ -134,13 +138,13 @@ func main() {
 
 	// Test with invalid token
 	fmt.Println("=== Testing Invalid Token ===")
+	invalidCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer invalid_token")
 	invalidReq := &userpb.UserListRequest{
-		Token: "invalid_token",
 		Page:  1,
 		Limit: 10,
 	}
 
-	_, err = client.GetUserList(ctx, invalidReq)
+	_, err = client.GetUserList(invalidCtx, invalidReq)
 	if err != nil {
 		fmt.Printf("✅ Expected error with invalid token: %v\n", err)
 	} else {
@@ -3,6 +3,10 @@ package config
 import (
 	"log"
 	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -10,18 +14,217 @@ import (
 type Config struct {
 	Database DatabaseConfig
 	Server   ServerConfig
+	JWT      JWTConfig
+	RBAC     RBACConfig
+	Login    LoginThrottleConfig
+	Mail     MailConfig
+	Reset    PasswordResetConfig
+	Verify   EmailVerificationConfig
+	MFA      MFAConfig
+	Password PasswordHashConfig
+	Policy   PasswordPolicyConfig
+	OAuth    OAuthConfig
+	OIDC     OIDCConfig
 }
 
 type DatabaseConfig struct {
+	// Driver is "mysql", "postgres" or "sqlite". SQLite keeps the database
+	// in the file Name and ignores the connection settings.
+	Driver   string
 	Host     string
 	Port     string
 	Username string
 	Password string
 	Name     string
+	// SSLMode is the PostgreSQL sslmode, e.g. "require" or "verify-full".
+	SSLMode string
+	// MigrateOnStart applies pending schema migrations when the server
+	// starts instead of refusing to run on an outdated schema.
+	MigrateOnStart bool
 }
 
 type ServerConfig struct {
 	Port string
+	// HTTPPort enables the HTTP listener (JWKS etc.) when non-empty.
+	HTTPPort string
+	// MetricsPort enables a separate HTTP listener for Prometheus metrics
+	// when non-empty. It belongs on an internal address, e.g. "127.0.0.1:9090".
+	MetricsPort string
+	// RPCTimeout is the deadline of gRPC calls whose client set none or a
+	// later one. RPCTimeouts overrides it by method name, e.g. "Login"; zero
+	// leaves a method without a deadline.
+	RPCTimeout  time.Duration
+	RPCTimeouts map[string]time.Duration
+}
+
+type JWTConfig struct {
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	// RevocationStore selects where revoked tokens are tracked: "database"
+	// or "memory" (single instance only).
+	RevocationStore           string
+	RevocationCleanupInterval time.Duration
+	// Signing key as PEM (RSA, P-256 ECDSA or Ed25519 private key) or an
+	// HMAC secret. SigningKeyFile takes precedence over SigningKey.
+	SigningKey     string
+	SigningKeyFile string
+	SigningKeyID   string
+	// SigningAlgorithm optionally pins the algorithm implied by the key.
+	SigningAlgorithm string
+	// VerificationKeyFiles maps key IDs to additional keys accepted for
+	// verification only, e.g. the previous signing key during rotation.
+	VerificationKeyFiles map[string]string
+}
+
+type RBACConfig struct {
+	// BootstrapAdmin is the username granted the admin role at startup.
+	BootstrapAdmin string
+	// BootstrapAdminPassword, if set, creates the bootstrap admin with this
+	// password when the username is not taken. Users kept in memory start
+	// out empty, so that is the only way to have an admin there.
+	BootstrapAdminPassword string
+}
+
+// LoginThrottleConfig controls backoff and lockout after failed logins.
+// Failures are counted per username and per client IP; once a key exceeds
+// its free attempts every further failure doubles the wait (from BackoffBase
+// up to BackoffMax), and reaching the lock threshold locks the key for
+// LockDuration. Counters restart after FailureWindow without failures, and
+// are deleted every CleanupInterval once they no longer throttle anything.
+type LoginThrottleConfig struct {
+	FreeAttempts    int
+	LockThreshold   int
+	IPFreeAttempts  int
+	IPLockThreshold int
+	BackoffBase     time.Duration
+	BackoffMax      time.Duration
+	LockDuration    time.Duration
+	FailureWindow   time.Duration
+	CleanupInterval time.Duration
+}
+
+// MailConfig selects how outgoing email is delivered: "smtp", "file"
+// (one .eml file per message in FileDir) or "log".
+type MailConfig struct {
+	Driver       string
+	From         string
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	FileDir      string
+}
+
+type PasswordResetConfig struct {
+	TokenTTL time.Duration
+	// URL is the page that completes the reset; the token is appended as the
+	// "token" query parameter. When empty the mail contains the bare token.
+	URL string
+	// At most AddressLimit requests per email address and IPLimit per
+	// client IP are accepted within RateWindow.
+	AddressLimit int
+	IPLimit      int
+	RateWindow   time.Duration
+	// MailWorkers send reset mail from a queue holding up to MailQueueSize
+	// waiting messages; requests beyond that are dropped.
+	MailWorkers   int
+	MailQueueSize int
+}
+
+// EmailVerificationConfig controls how unverified email addresses are
+// treated. Mode is "off" (no restriction), "limit" (unverified accounts can
+// sign in but get none of their role's permissions) or "block" (unverified
+// accounts cannot sign in, and are mailed a new link when they try). Accounts
+// without an email address are not restricted.
+type EmailVerificationConfig struct {
+	Mode     string
+	TokenTTL time.Duration
+	// URL is the page that completes verification, see PasswordResetConfig.
+	URL string
+}
+
+type MFAConfig struct {
+	// EncryptionKey is the base64-encoded 32-byte AES key protecting TOTP
+	// secrets at rest. Two-factor enrollment is unavailable without it.
+	EncryptionKey string
+	// Issuer is the account issuer shown in authenticator apps.
+	Issuer            string
+	ChallengeTTL      time.Duration
+	RecoveryCodeCount int
+}
+
+// PasswordHashConfig selects the algorithm for new password hashes
+// ("argon2id" or "bcrypt") and its parameters. Existing hashes made with
+// another algorithm or weaker parameters are upgraded at the next login.
+type PasswordHashConfig struct {
+	Algorithm string
+	// Argon2Memory is in KiB
+	Argon2Memory      uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+	Argon2SaltLength  uint32
+	Argon2KeyLength   uint32
+	BcryptCost        int
+	// Workers bounds how many hashes are computed at once, and QueueSize
+	// how many more may wait before requests are turned away.
+	Workers   int
+	QueueSize int
+}
+
+// PasswordPolicyConfig sets the rules new passwords must follow. Lengths are
+// counted in characters.
+type PasswordPolicyConfig struct {
+	MinLength     int
+	MaxLength     int
+	RequireUpper  bool
+	RequireLower  bool
+	RequireDigit  bool
+	RequireSymbol bool
+	// DisallowUserInfo rejects passwords containing the username or the
+	// local part of the email address.
+	DisallowUserInfo bool
+	// BlocklistFile names a file of common passwords, one per line, that
+	// are rejected regardless of case. Empty disables the blocklist.
+	BlocklistFile string
+	// HistorySize is how many of the most recent passwords, including the
+	// current one, may not be reused. Zero disables the check.
+	HistorySize int
+}
+
+// OAuthConfig controls the built-in OAuth 2.0 and OpenID Connect provider,
+// served on the HTTP listener. Issuer is the public URL of that listener,
+// such as "https://id.example.com"; the provider is off when it is empty.
+// It needs an asymmetric JWT signing key, so that clients can verify ID
+// tokens through the JWKS.
+type OAuthConfig struct {
+	Issuer string
+	// CodeTTL is how long an authorization code may wait to be exchanged.
+	CodeTTL time.Duration
+}
+
+// OIDCConfig lists the external OpenID Connect providers users may sign in
+// with through LoginWithOIDC, keyed by the name clients pass.
+type OIDCConfig struct {
+	Providers map[string]OIDCProviderConfig
+	// HTTPTimeout bounds each request to a provider.
+	HTTPTimeout time.Duration
+}
+
+// OIDCProviderConfig registers this service as a client of an upstream
+// issuer, whose endpoints are found through its discovery document.
+type OIDCProviderConfig struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURI is where the provider sent the user back with the code;
+	// it is repeated in the token request.
+	RedirectURI string
+	// TrustEmail links a first sign-in to the local account with the same
+	// email address, when the provider says the address is verified and it
+	// was verified here too. Only enable it for providers that own the
+	// domains their users sign in with. Addresses from other providers
+	// have to be verified by mail like any other.
+	TrustEmail bool
 }
 
 func Load() *Config {
@@ -30,23 +233,224 @@ func Load() *Config {
 		log.Println("No .env file found, using environment variables or defaults")
 	}
 
+	dbDriver := getEnv("DB_DRIVER", "mysql")
+	dbPort, dbName := "3306", "userdb"
+	switch dbDriver {
+	case "postgres":
+		dbPort = "5432"
+	case "sqlite":
+		dbName = "userdb.sqlite"
+	}
+
 	return &Config{
 		Database: DatabaseConfig{
+			Driver:   dbDriver,
 			Host:     getEnv("DB_HOST", "127.0.0.1"),
-			Port:     getEnv("DB_PORT", "3306"),
+			Port:     getEnv("DB_PORT", dbPort),
 			Username: getEnv("DB_USERNAME", "root"),
 			Password: getEnv("DB_PASSWORD", "password"),
-			Name:     getEnv("DB_NAME", "userdb"),
+			Name:     getEnv("DB_NAME", dbName),
+			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
+
+			MigrateOnStart: getEnvBool("DB_MIGRATE_ON_START", false),
 		},
 		Server: ServerConfig{
-			Port: getEnv("SERVER_PORT", ":50051"),
+			Port:     getEnv("SERVER_PORT", ":50051"),
+			HTTPPort: getEnv("SERVER_HTTP_PORT", ""),
+
+			MetricsPort: getEnv("SERVER_METRICS_PORT", ""),
+
+			RPCTimeout:  getEnvDuration("SERVER_RPC_TIMEOUT", 30*time.Second),
+			RPCTimeouts: getEnvDurationMap("SERVER_RPC_TIMEOUTS"),
+		},
+		JWT: JWTConfig{
+			AccessTokenTTL:            getEnvDuration("JWT_ACCESS_TOKEN_TTL", 15*time.Minute),
+			RefreshTokenTTL:           getEnvDuration("JWT_REFRESH_TOKEN_TTL", 30*24*time.Hour),
+			RevocationStore:           getEnv("JWT_REVOCATION_STORE", "database"),
+			RevocationCleanupInterval: getEnvDuration("JWT_REVOCATION_CLEANUP_INTERVAL", 10*time.Minute),
+			SigningKey:                getEnv("JWT_SIGNING_KEY", ""),
+			SigningKeyFile:            getEnv("JWT_SIGNING_KEY_FILE", ""),
+			SigningKeyID:              getEnv("JWT_SIGNING_KEY_ID", ""),
+			SigningAlgorithm:          getEnv("JWT_SIGNING_ALGORITHM", ""),
+			VerificationKeyFiles:      getEnvMap("JWT_VERIFICATION_KEY_FILES"),
+		},
+		RBAC: RBACConfig{
+			BootstrapAdmin:         getEnv("RBAC_BOOTSTRAP_ADMIN", ""),
+			BootstrapAdminPassword: getEnv("RBAC_BOOTSTRAP_ADMIN_PASSWORD", ""),
+		},
+		Login: LoginThrottleConfig{
+			FreeAttempts:    getEnvInt("LOGIN_FREE_ATTEMPTS", 3),
+			LockThreshold:   getEnvInt("LOGIN_LOCK_THRESHOLD", 10),
+			IPFreeAttempts:  getEnvInt("LOGIN_IP_FREE_ATTEMPTS", 20),
+			IPLockThreshold: getEnvInt("LOGIN_IP_LOCK_THRESHOLD", 100),
+			BackoffBase:     getEnvDuration("LOGIN_BACKOFF_BASE", time.Second),
+			BackoffMax:      getEnvDuration("LOGIN_BACKOFF_MAX", time.Minute),
+			LockDuration:    getEnvDuration("LOGIN_LOCK_DURATION", 15*time.Minute),
+			FailureWindow:   getEnvDuration("LOGIN_FAILURE_WINDOW", 15*time.Minute),
+			CleanupInterval: getEnvDuration("LOGIN_CLEANUP_INTERVAL", 10*time.Minute),
+		},
+		Mail: MailConfig{
+			Driver:       getEnv("MAIL_DRIVER", "log"),
+			From:         getEnv("MAIL_FROM", "no-reply@localhost"),
+			SMTPHost:     getEnv("MAIL_SMTP_HOST", "localhost"),
+			SMTPPort:     getEnv("MAIL_SMTP_PORT", "587"),
+			SMTPUsername: getEnv("MAIL_SMTP_USERNAME", ""),
+			SMTPPassword: getEnv("MAIL_SMTP_PASSWORD", ""),
+			FileDir:      getEnv("MAIL_FILE_DIR", "mail"),
+		},
+		Reset: PasswordResetConfig{
+			TokenTTL:      getEnvDuration("PASSWORD_RESET_TOKEN_TTL", 30*time.Minute),
+			URL:           getEnv("PASSWORD_RESET_URL", ""),
+			AddressLimit:  getEnvInt("PASSWORD_RESET_ADDRESS_LIMIT", 3),
+			IPLimit:       getEnvInt("PASSWORD_RESET_IP_LIMIT", 20),
+			RateWindow:    getEnvDuration("PASSWORD_RESET_RATE_WINDOW", 15*time.Minute),
+			MailWorkers:   getEnvInt("PASSWORD_RESET_MAIL_WORKERS", 2),
+			MailQueueSize: getEnvInt("PASSWORD_RESET_MAIL_QUEUE_SIZE", 100),
+		},
+		Verify: EmailVerificationConfig{
+			Mode:     getEnv("EMAIL_VERIFICATION_MODE", "off"),
+			TokenTTL: getEnvDuration("EMAIL_VERIFICATION_TOKEN_TTL", 48*time.Hour),
+			URL:      getEnv("EMAIL_VERIFICATION_URL", ""),
+		},
+		MFA: MFAConfig{
+			EncryptionKey:     getEnv("MFA_ENCRYPTION_KEY", ""),
+			Issuer:            getEnv("MFA_ISSUER", "go-grpc-starter"),
+			ChallengeTTL:      getEnvDuration("MFA_CHALLENGE_TTL", 5*time.Minute),
+			RecoveryCodeCount: getEnvInt("MFA_RECOVERY_CODE_COUNT", 10),
+		},
+		Password: PasswordHashConfig{
+			Algorithm:         getEnv("PASSWORD_HASH_ALGORITHM", "argon2id"),
+			Argon2Memory:      uint32(getEnvInt("PASSWORD_ARGON2_MEMORY", 64*1024)),
+			Argon2Iterations:  uint32(getEnvInt("PASSWORD_ARGON2_ITERATIONS", 3)),
+			Argon2Parallelism: uint8(getEnvInt("PASSWORD_ARGON2_PARALLELISM", 2)),
+			Argon2SaltLength:  16,
+			Argon2KeyLength:   32,
+			BcryptCost:        getEnvInt("PASSWORD_BCRYPT_COST", 12),
+			Workers:           getEnvInt("PASSWORD_HASH_WORKERS", runtime.GOMAXPROCS(0)),
+			QueueSize:         getEnvInt("PASSWORD_HASH_QUEUE_SIZE", 64),
+		},
+		Policy: PasswordPolicyConfig{
+			MinLength:        getEnvInt("PASSWORD_MIN_LENGTH", 8),
+			MaxLength:        getEnvInt("PASSWORD_MAX_LENGTH", 128),
+			RequireUpper:     getEnvBool("PASSWORD_REQUIRE_UPPER", false),
+			RequireLower:     getEnvBool("PASSWORD_REQUIRE_LOWER", false),
+			RequireDigit:     getEnvBool("PASSWORD_REQUIRE_DIGIT", false),
+			RequireSymbol:    getEnvBool("PASSWORD_REQUIRE_SYMBOL", false),
+			DisallowUserInfo: getEnvBool("PASSWORD_DISALLOW_USER_INFO", true),
+			BlocklistFile:    getEnv("PASSWORD_BLOCKLIST_FILE", ""),
+			HistorySize:      getEnvInt("PASSWORD_HISTORY_SIZE", 5),
+		},
+		OAuth: OAuthConfig{
+			Issuer:  strings.TrimSuffix(getEnv("OAUTH_ISSUER", ""), "/"),
+			CodeTTL: getEnvDuration("OAUTH_CODE_TTL", time.Minute),
+		},
+		OIDC: OIDCConfig{
+			Providers:   loadOIDCProviders(),
+			HTTPTimeout: getEnvDuration("OIDC_HTTP_TIMEOUT", 10*time.Second),
 		},
 	}
 }
 
+// loadOIDCProviders reads the providers named in OIDC_PROVIDERS, a
+// comma-separated list, from OIDC_<NAME>_ISSUER, OIDC_<NAME>_CLIENT_ID and
+// so on.
+func loadOIDCProviders() map[string]OIDCProviderConfig {
+	providers := make(map[string]OIDCProviderConfig)
+	for _, name := range strings.Split(getEnv("OIDC_PROVIDERS", ""), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		providers[name] = OIDCProviderConfig{
+			Issuer:       strings.TrimSuffix(getEnv(prefix+"ISSUER", ""), "/"),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			RedirectURI:  getEnv(prefix+"REDIRECT_URI", ""),
+			TrustEmail:   getEnvBool(prefix+"TRUST_EMAIL", false),
+		}
+	}
+	return providers
+}
+
 func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
 	}
 	return defaultValue
-} 
\ No newline at end of file
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("Invalid integer for %s=%q, using default %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return i
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("Invalid boolean for %s=%q, using default %t", key, value, defaultValue)
+		return defaultValue
+	}
+	return b
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("Invalid duration for %s=%q, using default %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return d
+}
+
+// getEnvDurationMap parses a comma-separated list of name=duration pairs.
+func getEnvDurationMap(key string) map[string]time.Duration {
+	result := make(map[string]time.Duration)
+	for name, value := range getEnvMap(key) {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			log.Printf("Ignoring invalid duration %q for %s in %s", value, name, key)
+			continue
+		}
+		result[name] = d
+	}
+	return result
+}
+
+// getEnvMap parses a comma-separated list of key=value pairs.
+func getEnvMap(key string) map[string]string {
+	result := make(map[string]string)
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return result
+	}
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		if !ok {
+			log.Printf("Ignoring malformed entry %q in %s, expected key=value", pair, key)
+			continue
+		}
+		result[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return result
+}
@@ -3,29 +3,71 @@ package config
 import (
 	"fmt"
 	"log"
+	"net"
+	"net/url"
 
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
 func (c *Config) ConnectDatabase() (*gorm.DB, error) {
-	// MySQL connection string
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?parseTime=true&charset=utf8mb4&collation=utf8mb4_unicode_ci",
-		c.Database.Username,
-		c.Database.Password,
-		c.Database.Host,
-		c.Database.Port,
-		c.Database.Name,
-	)
+	dialector, err := c.Database.dialector()
+	if err != nil {
+		return nil, err
+	}
 
-	log.Printf("Connecting to MySQL database: %s@%s:%s/%s",
-		c.Database.Username, c.Database.Host, c.Database.Port, c.Database.Name)
+	if c.Database.Driver == "sqlite" {
+		log.Printf("Opening SQLite database: %s", c.Database.Name)
+	} else {
+		log.Printf("Connecting to %s database: %s@%s:%s/%s", c.Database.Driver,
+			c.Database.Username, c.Database.Host, c.Database.Port, c.Database.Name)
+	}
 
-	db, err := gorm.Open(mysql.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(dialector, &gorm.Config{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to MySQL database: %w", err)
+		return nil, fmt.Errorf("failed to connect to %s database: %w", c.Database.Driver, err)
 	}
 
-	log.Println("MySQL database connected successfully")
+	log.Printf("%s database connected successfully", c.Database.Driver)
 	return db, nil
-} 
\ No newline at end of file
+}
+
+func (c DatabaseConfig) dialector() (gorm.Dialector, error) {
+	switch c.Driver {
+	case "mysql":
+		dsn := mysqldriver.NewConfig()
+		dsn.User = c.Username
+		dsn.Passwd = c.Password
+		dsn.Net = "tcp"
+		dsn.Addr = net.JoinHostPort(c.Host, c.Port)
+		dsn.DBName = c.Name
+		dsn.ParseTime = true
+		dsn.Collation = "utf8mb4_unicode_ci"
+		dsn.Params = map[string]string{"charset": "utf8mb4"}
+		return mysql.Open(dsn.FormatDSN()), nil
+	case "postgres":
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(c.Username, c.Password),
+			Host:     net.JoinHostPort(c.Host, c.Port),
+			Path:     "/" + c.Name,
+			RawQuery: url.Values{"sslmode": {c.SSLMode}}.Encode(),
+		}
+		return postgres.Open(dsn.String()), nil
+	case "sqlite":
+		// Writers wait for each other instead of failing with "database is
+		// locked", and transactions take the write lock up front, so that
+		// two of them can't deadlock upgrading their read locks
+		params := url.Values{
+			"_foreign_keys": {"1"},
+			"_journal_mode": {"WAL"},
+			"_busy_timeout": {"5000"},
+			"_txlock":       {"immediate"},
+		}
+		return sqlite.Open(c.Name + "?" + params.Encode()), nil
+	}
+	return nil, fmt.Errorf("unknown database driver %q, expected mysql, postgres or sqlite", c.Driver)
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// APIKey is a long-lived credential for scripts and service accounts. It
+// acts as its user, but only with the permissions in Scopes. Only the
+// SHA-256 hash of the secret is stored; Prefix identifies the key.
+type APIKey struct {
+	ID         uint         `gorm:"primaryKey" json:"id"`
+	UserID     uint         `gorm:"not null;index" json:"user_id"`
+	Name       string       `gorm:"not null;size:100" json:"name"`
+	Prefix     string       `gorm:"uniqueIndex;not null;size:16" json:"prefix"`
+	SecretHash string       `gorm:"not null;size:64" json:"-"`
+	Scopes     []Permission `gorm:"many2many:api_key_scopes;" json:"scopes"`
+	ExpiresAt  *time.Time   `json:"expires_at"`
+	LastUsedAt *time.Time   `json:"last_used_at"`
+	RevokedAt  *time.Time   `json:"revoked_at"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+// ScopeNames returns the names of the permissions the key may use.
+func (k *APIKey) ScopeNames() []string {
+	names := make([]string, 0, len(k.Scopes))
+	for _, scope := range k.Scopes {
+		names = append(names, scope.Name)
+	}
+	return names
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// ExternalIdentity links a local user to an account at an external OpenID
+// Connect provider, named as in the configuration. Subject is the
+// provider's stable user ID; Email is what the provider last reported and
+// is informational only.
+type ExternalIdentity struct {
+	ID          uint       `gorm:"primaryKey" json:"id"`
+	UserID      uint       `gorm:"not null;index" json:"user_id"`
+	Provider    string     `gorm:"not null;size:64;uniqueIndex:idx_external_identities_subject" json:"provider"`
+	Subject     string     `gorm:"not null;size:255;uniqueIndex:idx_external_identities_subject" json:"subject"`
+	Email       string     `gorm:"size:255" json:"email"`
+	LastLoginAt *time.Time `json:"last_login_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizeUsername returns the form usernames are compared in, so "Alice"
+// and "ALICE" name the same account. It applies NFKC normalization and
+// Unicode case folding.
+func NormalizeUsername(username string) string {
+	return foldIdentity(strings.TrimSpace(username))
+}
+
+// NormalizeEmail returns the form email addresses are compared in. Both the
+// local part and the domain are case folded: mail servers that treat the
+// local part case-sensitively are rare enough that telling such addresses
+// apart causes more duplicate accounts than it prevents. A trailing dot on
+// the domain is dropped.
+func NormalizeEmail(email string) string {
+	email = strings.TrimSpace(email)
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return foldIdentity(email)
+	}
+	local, domain := email[:at], strings.TrimSuffix(email[at+1:], ".")
+	return foldIdentity(local) + "@" + foldIdentity(domain)
+}
+
+// foldIdentity is NFKC_Casefold: folding may produce text that is no longer
+// normalized, so it is normalized on both sides.
+func foldIdentity(s string) string {
+	return norm.NFKC.String(cases.Fold().String(norm.NFKC.String(s)))
+}
+
+// NormalizeIdentity fills in the normalized username and email of user.
+func (u *User) NormalizeIdentity() {
+	username := NormalizeUsername(u.Username)
+	u.UsernameNormalized = &username
+	u.EmailNormalized = nil
+	if u.Email != nil && *u.Email != "" {
+		email := NormalizeEmail(*u.Email)
+		u.EmailNormalized = &email
+	}
+}
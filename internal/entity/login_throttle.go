@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// LoginThrottle counts recent failed logins for one throttling key, such as a
+// username or a client IP address.
+type LoginThrottle struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	ThrottleKey  string     `gorm:"uniqueIndex;not null;size:191" json:"throttle_key"`
+	FailedCount  int        `gorm:"not null;default:0" json:"failed_count"`
+	LastFailedAt time.Time  `json:"last_failed_at"`
+	LockedUntil  *time.Time `json:"locked_until"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// TOTPCredential is a user's authenticator app secret. It is inactive until
+// ConfirmedAt is set by a first valid code.
+type TOTPCredential struct {
+	ID     uint `gorm:"primaryKey" json:"id"`
+	UserID uint `gorm:"uniqueIndex;not null" json:"user_id"`
+	// SecretCiphertext is the encrypted secret, never the plain value.
+	SecretCiphertext string     `gorm:"not null;size:255" json:"-"`
+	ConfirmedAt      *time.Time `json:"confirmed_at"`
+	// LastUsedStep is the last accepted time step; a code is never accepted
+	// twice.
+	LastUsedStep int64     `gorm:"not null;default:0" json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// RecoveryCode is a single-use fallback for a lost authenticator. Only the
+// SHA-256 hash of the code is stored.
+type RecoveryCode struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	CodeHash  string     `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"slices"
+	"time"
+)
+
+// Grant types OAuth clients may be registered for. Refresh tokens are only
+// issued to clients with the refresh token grant.
+const (
+	OAuthGrantAuthorizationCode = "authorization_code"
+	OAuthGrantClientCredentials = "client_credentials"
+	OAuthGrantRefreshToken      = "refresh_token"
+)
+
+// OpenID Connect scopes. Clients may be registered for other scopes too,
+// which are passed through in access tokens for their own APIs.
+const (
+	OAuthScopeOpenID  = "openid"
+	OAuthScopeProfile = "profile"
+	OAuthScopeEmail   = "email"
+)
+
+// OAuthClient is an application that signs users in through the built-in
+// OAuth 2.0 provider, or calls APIs as itself. Public clients, such as
+// single-page apps, have no secret. Only the SHA-256 hash of the secret is
+// stored.
+type OAuthClient struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	ClientID     string    `gorm:"uniqueIndex;not null;size:64" json:"client_id"`
+	SecretHash   string    `gorm:"size:64" json:"-"`
+	Name         string    `gorm:"not null;size:100" json:"name"`
+	RedirectURIs []string  `gorm:"serializer:json;type:text" json:"redirect_uris"`
+	GrantTypes   []string  `gorm:"serializer:json;type:text" json:"grant_types"`
+	Scopes       []string  `gorm:"serializer:json;type:text" json:"scopes"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// Public reports whether the client has no secret.
+func (c *OAuthClient) Public() bool {
+	return c.SecretHash == ""
+}
+
+// AllowsGrant reports whether the client may use grantType.
+func (c *OAuthClient) AllowsGrant(grantType string) bool {
+	return slices.Contains(c.GrantTypes, grantType)
+}
+
+// AllowsRedirectURI reports whether uri is registered, compared exactly.
+func (c *OAuthClient) AllowsRedirectURI(uri string) bool {
+	return slices.Contains(c.RedirectURIs, uri)
+}
+
+// OAuthAuthorizationCode is the single-use code handed to a client's
+// redirect URI, to be exchanged for tokens with the PKCE code verifier. The
+// session is started when the user authorizes, and FamilyID names it; it is
+// empty for clients without the refresh token grant, which get no session.
+// RedirectURI is as given in the request, empty when the client relied on
+// its only registered URI; the token request must repeat it.
+type OAuthAuthorizationCode struct {
+	ID            uint       `gorm:"primaryKey" json:"id"`
+	CodeHash      string     `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	ClientID      string     `gorm:"not null;size:64;index" json:"client_id"`
+	UserID        uint       `gorm:"not null;index" json:"user_id"`
+	FamilyID      string     `gorm:"not null;size:64" json:"-"`
+	RedirectURI   string     `gorm:"type:text" json:"redirect_uri"`
+	Scope         string     `gorm:"size:1024" json:"scope"`
+	Nonce         string     `gorm:"size:255" json:"-"`
+	CodeChallenge string     `gorm:"not null;size:128" json:"-"`
+	ExpiresAt     time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt        *time.Time `json:"used_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// Purposes of one-time tokens.
+const (
+	TokenPurposePasswordReset     = "password_reset"
+	TokenPurposeEmailVerification = "email_verification"
+	TokenPurposeMFAChallenge      = "mfa_challenge"
+)
+
+// OneTimeToken is a single-use secret sent to a user out of band, such as a
+// password reset link. Only the SHA-256 hash of the secret is stored.
+type OneTimeToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	Purpose   string     `gorm:"not null;size:32;index" json:"purpose"`
+	TokenHash string     `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+	// Email is the address an email verification token was sent to, and
+	// the only one it verifies.
+	Email *string `gorm:"size:100" json:"-"`
+}
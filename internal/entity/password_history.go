@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// PasswordHistory keeps the hash of a password a user has replaced, so it
+// cannot be chosen again too soon.
+type PasswordHistory struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	UserID       uint      `gorm:"not null;index" json:"user_id"`
+	PasswordHash string    `gorm:"not null;size:255" json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
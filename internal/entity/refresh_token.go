@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// RefreshToken is a persisted, single-use refresh token. Tokens issued from
+// the same login share a FamilyID so that the whole chain can be revoked when
+// a token that was already exchanged is presented again.
+type RefreshToken struct {
+	ID        uint       `gorm:"primaryKey" json:"id"`
+	UserID    uint       `gorm:"not null;index" json:"user_id"`
+	FamilyID  string     `gorm:"not null;size:64;index" json:"family_id"`
+	TokenHash string     `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// RevokedToken blocks access tokens before their natural expiry. An entry
+// either targets one token by JTI, or, when JTI is empty, every token of
+// UserID issued at or before IssuedBefore. Entries are only needed until
+// ExpiresAt, after which the tokens they cover have expired on their own.
+type RevokedToken struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	JTI          string     `gorm:"size:64;index" json:"jti"`
+	UserID       uint       `gorm:"not null;index" json:"user_id"`
+	IssuedBefore *time.Time `json:"issued_before"`
+	ExpiresAt    time.Time  `gorm:"not null;index" json:"expires_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
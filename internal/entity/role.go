@@ -0,0 +1,72 @@
+package entity
+
+import "time"
+
+// Built-in roles. DefaultRoleID is what User.RoleID defaults to, so every
+// account that predates role management ends up as a regular user.
+const (
+	DefaultRoleID = 1
+	RoleNameUser  = "user"
+	RoleNameAdmin = "admin"
+)
+
+// Permissions checked by the usecase layer.
+const (
+	PermissionUsersRead          = "users:read"
+	PermissionUsersCreate        = "users:create"
+	PermissionUsersUpdate        = "users:update"
+	PermissionUsersDelete        = "users:delete"
+	PermissionUsersUnlock        = "users:unlock"
+	PermissionUsersSetPassword   = "users:set_password"
+	PermissionUsersSuspend       = "users:suspend"
+	PermissionUsersResetMFA      = "users:reset_mfa"
+	PermissionRolesManage        = "roles:manage"
+	PermissionRolesAssign        = "roles:assign"
+	PermissionAPIKeysManage      = "api_keys:manage"
+	PermissionSessionsManage     = "sessions:manage"
+	PermissionOAuthClientsManage = "oauth_clients:manage"
+	PermissionTokensIntrospect   = "tokens:introspect"
+)
+
+// AllPermissions is the permission catalog seeded at startup. The admin role
+// is always granted all of them.
+var AllPermissions = []Permission{
+	{Name: PermissionUsersRead, Description: "List and view any user"},
+	{Name: PermissionUsersCreate, Description: "Create users"},
+	{Name: PermissionUsersUpdate, Description: "Update any user"},
+	{Name: PermissionUsersDelete, Description: "Delete users"},
+	{Name: PermissionUsersUnlock, Description: "Clear login lockouts"},
+	{Name: PermissionUsersSetPassword, Description: "Set the password of any user"},
+	{Name: PermissionUsersSuspend, Description: "Suspend and reactivate users"},
+	{Name: PermissionUsersResetMFA, Description: "Turn off two-factor authentication of any user"},
+	{Name: PermissionRolesManage, Description: "Create, update and delete roles"},
+	{Name: PermissionRolesAssign, Description: "Assign roles to users"},
+	{Name: PermissionAPIKeysManage, Description: "Create, list and revoke API keys of any user"},
+	{Name: PermissionSessionsManage, Description: "List and revoke sessions of any user"},
+	{Name: PermissionOAuthClientsManage, Description: "Register and delete OAuth clients"},
+	{Name: PermissionTokensIntrospect, Description: "Introspect access tokens of any user"},
+}
+
+type Role struct {
+	ID          uint         `gorm:"primaryKey" json:"id"`
+	Name        string       `gorm:"uniqueIndex;not null;size:50" json:"name"`
+	Description string       `gorm:"size:255" json:"description"`
+	Permissions []Permission `gorm:"many2many:role_permissions;" json:"permissions"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+type Permission struct {
+	ID          uint   `gorm:"primaryKey" json:"id"`
+	Name        string `gorm:"uniqueIndex;not null;size:100" json:"name"`
+	Description string `gorm:"size:255" json:"description"`
+}
+
+// PermissionNames returns the names of the permissions granted to the role.
+func (r *Role) PermissionNames() []string {
+	names := make([]string, 0, len(r.Permissions))
+	for _, permission := range r.Permissions {
+		names = append(names, permission.Name)
+	}
+	return names
+}
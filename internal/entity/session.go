@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// Session is a device a user signed in on. It follows the refresh token
+// family issued at sign-in and ends when that family is revoked or expires.
+type Session struct {
+	ID        uint   `gorm:"primaryKey" json:"id"`
+	UserID    uint   `gorm:"not null;index" json:"user_id"`
+	FamilyID  string `gorm:"uniqueIndex;not null;size:64" json:"-"`
+	UserAgent string `gorm:"size:255" json:"user_agent"`
+	IPAddress string `gorm:"size:45" json:"ip_address"`
+	// ClientID and Scope are set when the user signed in to an OAuth client;
+	// the session's tokens are only good for that client.
+	ClientID   string    `gorm:"size:64" json:"client_id"`
+	Scope      string    `gorm:"size:1024" json:"scope"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
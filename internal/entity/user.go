@@ -6,18 +6,64 @@ import (
 	"gorm.io/gorm"
 )
 
+// UserStatus is the lifecycle state of an account. Only active accounts may
+// sign in or use their tokens.
+type UserStatus string
+
+const (
+	// UserStatusPending accounts wait for activation, such as self-registered
+	// accounts while email verification blocks sign-in.
+	UserStatusPending   UserStatus = "pending"
+	UserStatusActive    UserStatus = "active"
+	UserStatusSuspended UserStatus = "suspended"
+	// UserStatusLocked is a suspension for security reasons, e.g. a
+	// suspected account takeover.
+	UserStatusLocked  UserStatus = "locked"
+	UserStatusDeleted UserStatus = "deleted"
+)
+
 type User struct {
-	ID        uint           `gorm:"primaryKey" json:"id"`
-	Username  string         `gorm:"uniqueIndex;not null;size:30" json:"username"`
-	Name      string         `gorm:"not null;size:100" json:"name"`
-	Email     *string        `gorm:"uniqueIndex;size:100" json:"email"`
-	Phone     string         `gorm:"size:20" json:"phone"`
-	Mobile    string         `gorm:"size:20" json:"mobile"`
-	ImageURL  string         `gorm:"size:255" json:"image_url"`
-	Password  string         `gorm:"not null;size:255" json:"-"`
-	IsActive  *bool          `gorm:"default:true" json:"is_active"`
-	RoleID    int            `gorm:"not null;default:1" json:"role_id"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	ID       uint    `gorm:"primaryKey" json:"id"`
+	Username string  `gorm:"uniqueIndex;not null;size:30" json:"username"`
+	Name     string  `gorm:"not null;size:100" json:"name"`
+	Email    *string `gorm:"uniqueIndex;size:100" json:"email"`
+	// UsernameNormalized and EmailNormalized are the forms lookups and
+	// uniqueness checks use, see NormalizeUsername and NormalizeEmail. They
+	// are only nil for accounts that collided when the columns were added.
+	UsernameNormalized *string `gorm:"uniqueIndex;size:64" json:"-"`
+	EmailNormalized    *string `gorm:"uniqueIndex;size:255" json:"-"`
+	// EmailVerifiedAt is nil until the user proves they own Email.
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	Phone           string     `gorm:"size:20" json:"phone"`
+	Mobile          string     `gorm:"size:20" json:"mobile"`
+	ImageURL        string     `gorm:"size:255" json:"image_url"`
+	Password        string     `gorm:"not null;size:255" json:"-"`
+	// PasswordChangedAt is when Password was last set by the user or an
+	// administrator; access tokens issued earlier are rejected.
+	PasswordChangedAt *time.Time `json:"password_changed_at"`
+	// PasswordChangeRequired withholds the role's permissions until the
+	// user picks a new password.
+	PasswordChangeRequired bool       `gorm:"not null;default:false" json:"password_change_required"`
+	Status                 UserStatus `gorm:"not null;size:16;default:active;index" json:"status"`
+	StatusReason           string     `gorm:"size:255" json:"status_reason"`
+	// StatusExpiresAt ends a suspension or lock on its own, see EffectiveStatus.
+	StatusExpiresAt *time.Time     `json:"status_expires_at"`
+	RoleID          int            `gorm:"not null;default:1" json:"role_id"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// EffectiveStatus is Status, except that a suspension or lock past its
+// expiry counts as active.
+func (u *User) EffectiveStatus(now time.Time) UserStatus {
+	switch u.Status {
+	case UserStatusSuspended, UserStatusLocked:
+		if u.StatusExpiresAt != nil && !now.Before(*u.StatusExpiresAt) {
+			return UserStatusActive
+		}
+	case "":
+		return UserStatusActive
+	}
+	return u.Status
 }
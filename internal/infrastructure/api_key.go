@@ -0,0 +1,40 @@
+package infrastructure
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+)
+
+// apiKeyMarker starts every API key, which tells them apart from JWTs.
+const apiKeyMarker = "ak_"
+
+// GenerateAPIKey returns a new key of the form "ak_<id>.<secret>" and its
+// prefix "ak_<id>", which is stored in plain text to find the key again.
+func GenerateAPIKey() (key, prefix string, err error) {
+	id := make([]byte, 6)
+	if _, err := rand.Read(id); err != nil {
+		return "", "", err
+	}
+	secret, err := GenerateOpaqueToken(32)
+	if err != nil {
+		return "", "", err
+	}
+	prefix = apiKeyMarker + hex.EncodeToString(id)
+	return prefix + "." + secret, prefix, nil
+}
+
+// IsAPIKey reports whether a bearer credential looks like an API key rather
+// than an access token.
+func IsAPIKey(token string) bool {
+	return strings.HasPrefix(token, apiKeyMarker)
+}
+
+// ParseAPIKey splits a key made by GenerateAPIKey into its prefix and secret.
+func ParseAPIKey(key string) (prefix, secret string, ok bool) {
+	prefix, secret, ok = strings.Cut(key, ".")
+	if !ok || !IsAPIKey(prefix) || secret == "" {
+		return "", "", false
+	}
+	return prefix, secret, true
+}
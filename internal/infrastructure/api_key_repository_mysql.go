@@ -0,0 +1,55 @@
+package infrastructure
+
+import (
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository struct {
+	DB *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{DB: db}
+}
+
+func (r *APIKeyRepository) Create(key *entity.APIKey) error {
+	// The scopes come from the permission catalog and are only linked
+	return r.DB.Omit("Scopes.*").Create(key).Error
+}
+
+func (r *APIKeyRepository) FindByID(id uint) (*entity.APIKey, error) {
+	var key entity.APIKey
+	err := r.DB.Preload("Scopes").First(&key, id).Error
+	return &key, err
+}
+
+func (r *APIKeyRepository) FindByPrefix(prefix string) (*entity.APIKey, error) {
+	var key entity.APIKey
+	err := r.DB.Preload("Scopes").Where("prefix = ?", prefix).First(&key).Error
+	return &key, err
+}
+
+func (r *APIKeyRepository) ListByUser(userID uint) ([]*entity.APIKey, error) {
+	var keys []*entity.APIKey
+	err := r.DB.Preload("Scopes").Where("user_id = ?", userID).Order("id ASC").Find(&keys).Error
+	return keys, err
+}
+
+func (r *APIKeyRepository) Revoke(id uint) error {
+	return r.DB.Model(&entity.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *APIKeyRepository) RevokeAllForUser(userID uint) error {
+	return r.DB.Model(&entity.APIKey{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *APIKeyRepository) MarkUsed(id uint, usedAt time.Time) error {
+	return r.DB.Model(&entity.APIKey{}).Where("id = ?", id).Update("last_used_at", usedAt).Error
+}
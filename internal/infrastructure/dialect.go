@@ -0,0 +1,72 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	mysqldriver "github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+)
+
+// likeEscape is the escape character of the patterns from containsPattern.
+// Backslash, the default of MySQL and PostgreSQL, is itself an escape in
+// MySQL string literals, and SQLite has no default.
+const likeEscape = "!"
+
+var likeEscaper = strings.NewReplacer(likeEscape, likeEscape+likeEscape, "%", likeEscape+"%", "_", likeEscape+"_")
+
+// caseInsensitiveLike returns the operator matching patterns regardless of
+// case. LIKE already does on MySQL, through the collation, and on SQLite,
+// for ASCII letters.
+func caseInsensitiveLike(db *gorm.DB) string {
+	if db.Dialector.Name() == "postgres" {
+		return "ILIKE"
+	}
+	return "LIKE"
+}
+
+// containsPattern returns a LIKE pattern matching s anywhere, with the
+// wildcards in s taken literally. Use it with ESCAPE '!'.
+func containsPattern(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+// uniqueViolation reports whether err is the violation of a unique index,
+// and of which: its name on MySQL and PostgreSQL, and the "table.column"
+// list SQLite reports instead.
+func uniqueViolation(err error) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+	var mysqlErr *mysqldriver.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == 1062 {
+		// Duplicate entry 'x' for key 'users.idx_users_email'; MySQL 5.7
+		// leaves out the table
+		_, key, _ := strings.Cut(mysqlErr.Message, " for key '")
+		key = strings.TrimSuffix(key, "'")
+		if i := strings.LastIndex(key, "."); i >= 0 {
+			key = key[i+1:]
+		}
+		return key, true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return pgErr.ConstraintName, true
+	}
+
+	// The error type of the SQLite driver only exists in cgo builds, which
+	// MySQL and PostgreSQL deployments need not be
+	if columns, ok := strings.CutPrefix(err.Error(), "UNIQUE constraint failed: "); ok {
+		return columns, true
+	}
+	return "", false
+}
+
+// duplicateKey returns the violation of the unique index key as
+// gorm.ErrDuplicatedKey, naming the index.
+func duplicateKey(key string) error {
+	return fmt.Errorf("%w: %s", gorm.ErrDuplicatedKey, key)
+}
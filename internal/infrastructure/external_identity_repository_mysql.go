@@ -0,0 +1,33 @@
+package infrastructure
+
+import (
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"gorm.io/gorm"
+)
+
+type ExternalIdentityRepository struct {
+	DB *gorm.DB
+}
+
+func NewExternalIdentityRepository(db *gorm.DB) *ExternalIdentityRepository {
+	return &ExternalIdentityRepository{DB: db}
+}
+
+func (r *ExternalIdentityRepository) Create(identity *entity.ExternalIdentity) error {
+	return r.DB.Create(identity).Error
+}
+
+func (r *ExternalIdentityRepository) Find(provider, subject string) (*entity.ExternalIdentity, error) {
+	var identity entity.ExternalIdentity
+	err := r.DB.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	return &identity, err
+}
+
+func (r *ExternalIdentityRepository) MarkLogin(id uint, email string, at time.Time) error {
+	return r.DB.Model(&entity.ExternalIdentity{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"email":         email,
+		"last_login_at": at,
+	}).Error
+}
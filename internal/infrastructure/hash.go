@@ -1,13 +1,52 @@
 package infrastructure
 
-import "golang.org/x/crypto/bcrypt"
+import (
+	"errors"
+	"fmt"
+	"strings"
 
-func HashPassword(password string) (string, error) {
-  bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
-  return string(bytes), err
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptHasher produces standard "$2a$<cost>$..." bcrypt hashes.
+type BcryptHasher struct {
+	cost int
+}
+
+func NewBcryptHasher(cost int) (*BcryptHasher, error) {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+	}
+	return &BcryptHasher{cost: cost}, nil
+}
+
+func (h *BcryptHasher) Hash(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), h.cost)
+	if errors.Is(err, bcrypt.ErrPasswordTooLong) {
+		return "", ErrPasswordTooLong
+	}
+	return string(bytes), err
 }
 
-func CheckPasswordHash(hash, password string) bool {
-  err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
-  return err == nil
-}
\ No newline at end of file
+func (h *BcryptHasher) Verify(encodedHash, password string) (bool, error) {
+	err := bcrypt.CompareHashAndPassword([]byte(encodedHash), []byte(password))
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, bcrypt.ErrMismatchedHashAndPassword), errors.Is(err, bcrypt.ErrPasswordTooLong):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (h *BcryptHasher) NeedsRehash(encodedHash string) bool {
+	cost, err := bcrypt.Cost([]byte(encodedHash))
+	return err != nil || cost != h.cost
+}
+
+func (h *BcryptHasher) Recognizes(encodedHash string) bool {
+	return strings.HasPrefix(encodedHash, "$2a$") ||
+		strings.HasPrefix(encodedHash, "$2b$") ||
+		strings.HasPrefix(encodedHash, "$2y$")
+}
@@ -0,0 +1,144 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// ErrHashingQueueFull is returned when every worker is busy and the queue has
+// no room left, so the caller should retry later instead of piling up.
+var ErrHashingQueueFull = errors.New("password hashing queue is full")
+
+var (
+	hashQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "password_hash_queue_depth",
+		Help: "Password hashing jobs waiting for a worker.",
+	})
+	hashQueueWait = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "password_hash_queue_wait_seconds",
+		Help:    "Time password hashing jobs spent waiting for a worker.",
+		Buckets: []float64{.001, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	})
+	hashJobsRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "password_hash_jobs_rejected_total",
+		Help: "Password hashing jobs not run, by reason.",
+	}, []string{"reason"})
+)
+
+// HashingPool runs password hashing on a fixed number of workers. Hashing is
+// deliberately expensive, so running it on the request goroutines lets a
+// burst of logins pin every core and starve unrelated RPCs.
+type HashingPool struct {
+	hasher PasswordHasher
+	jobs   chan *hashJob
+}
+
+type hashJob struct {
+	ctx      context.Context
+	run      func() (string, bool, error)
+	queuedAt time.Time
+	done     chan hashResult
+}
+
+type hashResult struct {
+	hash    string
+	matched bool
+	err     error
+}
+
+// NewHashingPool starts workers goroutines that take jobs from a queue
+// holding up to queueSize waiting jobs.
+func NewHashingPool(hasher PasswordHasher, workers, queueSize int) (*HashingPool, error) {
+	if workers < 1 {
+		return nil, fmt.Errorf("password hashing workers must be at least 1, got %d", workers)
+	}
+	if queueSize < 0 {
+		return nil, fmt.Errorf("password hashing queue size must not be negative, got %d", queueSize)
+	}
+
+	p := &HashingPool{
+		hasher: hasher,
+		jobs:   make(chan *hashJob, queueSize),
+	}
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p, nil
+}
+
+// Hash hashes password on a worker. It returns ErrHashingQueueFull without
+// waiting when the queue is full, and ctx.Err() when ctx ends first.
+func (p *HashingPool) Hash(ctx context.Context, password string) (string, error) {
+	result, err := p.submit(ctx, func() (string, bool, error) {
+		hash, err := p.hasher.Hash(password)
+		return hash, false, err
+	})
+	return result.hash, err
+}
+
+// Verify reports on a worker whether password matches encodedHash, with the
+// same queueing behaviour as Hash.
+func (p *HashingPool) Verify(ctx context.Context, encodedHash, password string) (bool, error) {
+	result, err := p.submit(ctx, func() (string, bool, error) {
+		matched, err := p.hasher.Verify(encodedHash, password)
+		return "", matched, err
+	})
+	return result.matched, err
+}
+
+// NeedsRehash only parses the hash, so it runs on the caller's goroutine.
+func (p *HashingPool) NeedsRehash(encodedHash string) bool {
+	return p.hasher.NeedsRehash(encodedHash)
+}
+
+func (p *HashingPool) submit(ctx context.Context, run func() (string, bool, error)) (hashResult, error) {
+	if err := ctx.Err(); err != nil {
+		return hashResult{}, err
+	}
+
+	job := &hashJob{
+		ctx:      ctx,
+		run:      run,
+		queuedAt: time.Now(),
+		// Buffered so a worker never blocks on a caller that gave up
+		done: make(chan hashResult, 1),
+	}
+	// Counted before the send, as a worker may take the job right away
+	hashQueueDepth.Inc()
+	select {
+	case p.jobs <- job:
+	default:
+		hashQueueDepth.Dec()
+		hashJobsRejected.WithLabelValues("queue_full").Inc()
+		return hashResult{}, ErrHashingQueueFull
+	}
+
+	select {
+	case result := <-job.done:
+		return result, result.err
+	case <-ctx.Done():
+		return hashResult{}, ctx.Err()
+	}
+}
+
+func (p *HashingPool) work() {
+	for job := range p.jobs {
+		hashQueueDepth.Dec()
+		hashQueueWait.Observe(time.Since(job.queuedAt).Seconds())
+
+		// Nobody is waiting for the result any more, don't burn CPU on it
+		if err := job.ctx.Err(); err != nil {
+			hashJobsRejected.WithLabelValues("canceled").Inc()
+			job.done <- hashResult{err: err}
+			continue
+		}
+
+		hash, matched, err := job.run()
+		job.done <- hashResult{hash: hash, matched: matched, err: err}
+	}
+}
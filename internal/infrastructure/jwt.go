@@ -2,36 +2,171 @@ package infrastructure
 
 import (
 	"errors"
+	"strconv"
 	"time"
 
+	"github.com/aungmyozaw92/go-grpc-starter/internal/repository"
 	"github.com/golang-jwt/jwt/v5"
 )
 
-var jwtKey = []byte("secretKey")
+// issuedAtPrecision is the resolution of the iat claim of issued tokens:
+// whole seconds, the library default, which relying parties that parse the
+// claims of OAuth and ID tokens as integers expect.
+const issuedAtPrecision = time.Second
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrRevokedToken = errors.New("token has been revoked")
+)
 
 type JWTClaim struct {
-  UserID int `json:"user_id"`
-  jwt.RegisteredClaims
-}
-
-func GenerateJWT(userID int) (string, error) {
-  claims := &JWTClaim{
-    UserID: userID,
-    RegisteredClaims: jwt.RegisteredClaims{
-      ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
-    },
-  }
-  token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-  return token.SignedString(jwtKey)
-}
-
-func ValidateToken(tokenStr string) (int, error) {
-  claims := &JWTClaim{}
-  token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
-    return jwtKey, nil
-  })
-  if err != nil || !token.Valid {
-    return 0, errors.New("invalid token")
-  }
-  return claims.UserID, nil
-}
\ No newline at end of file
+	UserID int `json:"user_id"`
+	// SessionID names the session the token was issued to, so it ends with
+	// the session. Tokens issued before sessions existed have none.
+	SessionID string `json:"sid,omitempty"`
+	// ClientID and Scope are set on tokens issued to OAuth clients, which
+	// only the OAuth endpoints accept. Client credentials tokens have no
+	// user; their subject is the client.
+	ClientID string `json:"client_id,omitempty"`
+	Scope    string `json:"scope,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// OIDCUserClaims are the standard claims about a user released for the
+// OpenID Connect profile and email scopes.
+type OIDCUserClaims struct {
+	Name              string `json:"name,omitempty"`
+	PreferredUsername string `json:"preferred_username,omitempty"`
+	Email             string `json:"email,omitempty"`
+	EmailVerified     *bool  `json:"email_verified,omitempty"`
+}
+
+// IDTokenClaim is an OpenID Connect ID token. It has no jti, so it is never
+// accepted as an access token.
+type IDTokenClaim struct {
+	Nonce           string `json:"nonce,omitempty"`
+	AuthorizedParty string `json:"azp,omitempty"`
+	OIDCUserClaims
+	jwt.RegisteredClaims
+}
+
+// JWTManager issues and validates short-lived access tokens. Every token
+// carries a unique ID (jti) so it can be revoked before it expires, and a kid
+// header naming the key that signed it.
+type JWTManager struct {
+	keys           *JWTKeySet
+	accessTokenTTL time.Duration
+	revocations    repository.TokenRevocationRepository
+}
+
+func NewJWTManager(keys *JWTKeySet, accessTokenTTL time.Duration, revocations repository.TokenRevocationRepository) *JWTManager {
+	return &JWTManager{keys: keys, accessTokenTTL: accessTokenTTL, revocations: revocations}
+}
+
+func (m *JWTManager) GenerateJWT(userID int, sessionID string) (string, time.Time, error) {
+	return m.generate(&JWTClaim{
+		UserID:           userID,
+		SessionID:        sessionID,
+		RegisteredClaims: jwt.RegisteredClaims{Subject: strconv.Itoa(userID)},
+	})
+}
+
+// GenerateOAuthJWT issues an access token to an OAuth client, acting as
+// userID within scope, or as the client itself when userID is 0.
+func (m *JWTManager) GenerateOAuthJWT(issuer string, userID int, sessionID, clientID, scope string) (string, time.Time, error) {
+	subject := clientID
+	if userID != 0 {
+		subject = strconv.Itoa(userID)
+	}
+	return m.generate(&JWTClaim{
+		UserID:    userID,
+		SessionID: sessionID,
+		ClientID:  clientID,
+		Scope:     scope,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:   issuer,
+			Subject:  subject,
+			Audience: jwt.ClaimStrings{clientID},
+		},
+	})
+}
+
+// generate fills in the token ID, issue time and expiry and signs claims.
+func (m *JWTManager) generate(claims *JWTClaim) (string, time.Time, error) {
+	jti, err := GenerateOpaqueToken(16)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	now := time.Now().Truncate(issuedAtPrecision)
+	expiresAt := now.Add(m.accessTokenTTL)
+	claims.ID = jti
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(expiresAt)
+	signed, err := m.sign(claims)
+	return signed, expiresAt, err
+}
+
+// SignIDToken signs an ID token. The caller sets every claim, including the
+// expiry.
+func (m *JWTManager) SignIDToken(claims *IDTokenClaim) (string, error) {
+	return m.sign(claims)
+}
+
+func (m *JWTManager) sign(claims jwt.Claims) (string, error) {
+	key := m.keys.Signing()
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.PrivateKey)
+}
+
+// ParseToken verifies the token signature, expiry and revocation status and
+// returns its claims.
+func (m *JWTManager) ParseToken(tokenStr string) (*JWTClaim, error) {
+	claims := &JWTClaim{}
+	token, err := jwt.ParseWithClaims(tokenStr, claims, m.keys.keyFunc,
+		jwt.WithIssuedAt(), jwt.WithExpirationRequired())
+	if err != nil || !token.Valid || claims.ID == "" || claims.IssuedAt == nil {
+		return nil, ErrInvalidToken
+	}
+
+	revoked, err := m.revocations.IsRevoked(claims.ID, uint(claims.UserID), claims.IssuedAt.Time)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrRevokedToken
+	}
+	return claims, nil
+}
+
+func (m *JWTManager) ValidateToken(tokenStr string) (int, error) {
+	claims, err := m.ParseToken(tokenStr)
+	if err != nil {
+		return 0, err
+	}
+	return claims.UserID, nil
+}
+
+// RevokeToken blocks a single token until it would have expired anyway.
+func (m *JWTManager) RevokeToken(jti string, userID uint, expiresAt time.Time) error {
+	return m.revocations.RevokeToken(jti, userID, expiresAt)
+}
+
+// RevokeAllForUser blocks every token issued to the user up to now. The entry
+// only has to outlive the longest-lived token it covers.
+func (m *JWTManager) RevokeAllForUser(userID uint) error {
+	cutoff := RevocationCutoff()
+	return m.revocations.RevokeAllForUser(userID, cutoff, cutoff.Add(m.accessTokenTTL))
+}
+
+// RevocationCutoff returns the current time at iat resolution, for revoking
+// every token issued at or before it. It returns once that second has
+// passed, so tokens issued after the call are never caught by the cutoff;
+// callers wait up to a second for it.
+// Instances whose clocks disagree by more than that can still let a token
+// slip through or reject a fresh one; clock sync is assumed.
+func RevocationCutoff() time.Time {
+	cutoff := time.Now().Truncate(issuedAtPrecision)
+	time.Sleep(time.Until(cutoff.Add(issuedAtPrecision)))
+	return cutoff
+}
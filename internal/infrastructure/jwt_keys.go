@@ -0,0 +1,244 @@
+package infrastructure
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/aungmyozaw92/go-grpc-starter/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minHMACKeySize is the shortest HS256 secret accepted, matching the output
+// size of SHA-256.
+const minHMACKeySize = 32
+
+// JWTKey is a single signing or verification key. PrivateKey is nil for keys
+// that are only trusted for verification, such as a retired signing key that
+// is kept around until the tokens it signed have expired.
+type JWTKey struct {
+	ID         string
+	Method     jwt.SigningMethod
+	PrivateKey interface{}
+	PublicKey  interface{}
+}
+
+// JWTKeySet holds the active signing key and every key accepted for
+// verification, indexed by key ID (the JWT "kid" header).
+type JWTKeySet struct {
+	signing      *JWTKey
+	verification map[string]*JWTKey
+}
+
+// NewJWTKeySet builds a key set from a signing key and any number of
+// additional verification-only keys.
+func NewJWTKeySet(signing *JWTKey, verification ...*JWTKey) (*JWTKeySet, error) {
+	if signing == nil || signing.PrivateKey == nil {
+		return nil, errors.New("a signing key with private material is required")
+	}
+	set := &JWTKeySet{
+		signing:      signing,
+		verification: map[string]*JWTKey{signing.ID: signing},
+	}
+	for _, key := range verification {
+		if _, exists := set.verification[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate JWT key id %q", key.ID)
+		}
+		set.verification[key.ID] = key
+	}
+	return set, nil
+}
+
+// LoadJWTKeySet reads the signing key and verification keys described by the
+// configuration. When no signing key is configured an ephemeral HS256 secret
+// is generated, which invalidates all tokens on restart; this is only meant
+// for local development.
+func LoadJWTKeySet(cfg config.JWTConfig) (*JWTKeySet, error) {
+	material, err := readKeyMaterial(cfg.SigningKey, cfg.SigningKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWT signing key: %w", err)
+	}
+	if material == nil {
+		log.Println("No JWT signing key configured, generating an ephemeral HS256 key; tokens will not survive a restart")
+		secret := make([]byte, minHMACKeySize)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, err
+		}
+		// Hex encoded, raw bytes could start or end with whitespace that
+		// ParseJWTKey trims off
+		material = []byte(hex.EncodeToString(secret))
+	}
+
+	signing, err := ParseJWTKey(cfg.SigningKeyID, material)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT signing key: %w", err)
+	}
+	if signing.PrivateKey == nil {
+		return nil, errors.New("JWT signing key must be a private key or HMAC secret")
+	}
+	if cfg.SigningAlgorithm != "" && cfg.SigningAlgorithm != signing.Method.Alg() {
+		return nil, fmt.Errorf("JWT signing key is %s but JWT_SIGNING_ALGORITHM is %s",
+			signing.Method.Alg(), cfg.SigningAlgorithm)
+	}
+
+	// Sort for deterministic error reporting
+	kids := make([]string, 0, len(cfg.VerificationKeyFiles))
+	for kid := range cfg.VerificationKeyFiles {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+
+	var verification []*JWTKey
+	for _, kid := range kids {
+		data, err := os.ReadFile(cfg.VerificationKeyFiles[kid])
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT verification key %q: %w", kid, err)
+		}
+		key, err := ParseJWTKey(kid, data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT verification key %q: %w", kid, err)
+		}
+		verification = append(verification, key)
+	}
+
+	return NewJWTKeySet(signing, verification...)
+}
+
+func readKeyMaterial(inline, file string) ([]byte, error) {
+	if file != "" {
+		return os.ReadFile(file)
+	}
+	if inline != "" {
+		return []byte(inline), nil
+	}
+	return nil, nil
+}
+
+// ParseJWTKey parses PEM-encoded RSA, ECDSA P-256 or Ed25519 keys (private or
+// public) and treats anything that is not PEM as an HMAC secret. The signing
+// algorithm follows from the key type. When kid is empty it is derived from
+// the public key (or secret) so that it stays stable across restarts.
+func ParseJWTKey(kid string, data []byte) (*JWTKey, error) {
+	key := &JWTKey{ID: kid}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		secret := []byte(strings.TrimSpace(string(data)))
+		if len(secret) < minHMACKeySize {
+			return nil, fmt.Errorf("HMAC secret must be at least %d bytes", minHMACKeySize)
+		}
+		key.Method = jwt.SigningMethodHS256
+		key.PrivateKey = secret
+		key.PublicKey = secret
+		if key.ID == "" {
+			key.ID = deriveKeyID(secret)
+		}
+		return key, nil
+	}
+
+	parsed, err := parsePEMBlock(block)
+	if err != nil {
+		return nil, err
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.PrivateKey, key.PublicKey = k, &k.PublicKey
+	case *ecdsa.PrivateKey:
+		key.PrivateKey, key.PublicKey = k, &k.PublicKey
+	case ed25519.PrivateKey:
+		key.PrivateKey, key.PublicKey = k, k.Public()
+	default:
+		key.PublicKey = k
+	}
+
+	switch pub := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		key.Method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		if pub.Curve != elliptic.P256() {
+			return nil, errors.New("only P-256 ECDSA keys are supported (ES256)")
+		}
+		key.Method = jwt.SigningMethodES256
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", pub)
+	}
+
+	if key.ID == "" {
+		der, err := x509.MarshalPKIXPublicKey(key.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		key.ID = deriveKeyID(der)
+	}
+	return key, nil
+}
+
+func parsePEMBlock(block *pem.Block) (interface{}, error) {
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		return x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+}
+
+func deriveKeyID(material []byte) string {
+	sum := sha256.Sum256(material)
+	return hex.EncodeToString(sum[:8])
+}
+
+// Signing returns the key new tokens are signed with.
+func (s *JWTKeySet) Signing() *JWTKey {
+	return s.signing
+}
+
+// Asymmetric reports whether the signing key has a public half, so that
+// other parties can verify its tokens through the JWKS.
+func (s *JWTKeySet) Asymmetric() bool {
+	_, hmac := s.signing.Method.(*jwt.SigningMethodHMAC)
+	return !hmac
+}
+
+// Lookup returns the verification key for a key ID.
+func (s *JWTKeySet) Lookup(kid string) (*JWTKey, bool) {
+	key, ok := s.verification[kid]
+	return key, ok
+}
+
+// keyFunc resolves the verification key from the token's kid header and
+// refuses tokens whose alg does not match that key, so an RSA public key can
+// never be used as an HMAC secret.
+func (s *JWTKeySet) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.Lookup(kid)
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+	return key.PublicKey, nil
+}
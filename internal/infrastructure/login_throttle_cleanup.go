@@ -0,0 +1,39 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/repository"
+)
+
+// StartLoginThrottleCleanup periodically purges login throttles that no
+// longer throttle anything until ctx is cancelled. Failed logins for names
+// that do not exist would otherwise pile up forever.
+func StartLoginThrottleCleanup(ctx context.Context, repo repository.LoginThrottleRepository, window, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("login throttle cleanup interval must be positive, got %s", interval)
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				deleted, err := repo.DeleteExpired(now, window)
+				if err != nil {
+					log.Printf("Failed to clean up login throttles: %v", err)
+					continue
+				}
+				if deleted > 0 {
+					log.Printf("Cleaned up %d expired login throttles", deleted)
+				}
+			}
+		}
+	}()
+	return nil
+}
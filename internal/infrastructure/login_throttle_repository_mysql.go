@@ -0,0 +1,110 @@
+package infrastructure
+
+import (
+	"slices"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type LoginThrottleRepository struct {
+	DB *gorm.DB
+}
+
+func NewLoginThrottleRepository(db *gorm.DB) *LoginThrottleRepository {
+	return &LoginThrottleRepository{DB: db}
+}
+
+func (r *LoginThrottleRepository) FindByKeys(keys []string) ([]*entity.LoginThrottle, error) {
+	var throttles []*entity.LoginThrottle
+	err := r.DB.Where("throttle_key IN ?", keys).Find(&throttles).Error
+	return throttles, err
+}
+
+func (r *LoginThrottleRepository) Reserve(keys []string, now time.Time, window time.Duration, admit func([]*entity.LoginThrottle) error) error {
+	// Always lock in the same order, or two reservations can deadlock
+	keys = slices.Sorted(slices.Values(keys))
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		throttles := make([]*entity.LoginThrottle, 0, len(keys))
+		for _, key := range keys {
+			// Make sure the row exists so concurrent attempts serialize on it
+			if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&entity.LoginThrottle{ThrottleKey: key, LastFailedAt: now}).Error; err != nil {
+				return err
+			}
+
+			var throttle entity.LoginThrottle
+			err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+				Where("throttle_key = ?", key).First(&throttle).Error
+			if err != nil {
+				return err
+			}
+			if now.Sub(throttle.LastFailedAt) > window {
+				throttle.FailedCount = 0
+			}
+			throttles = append(throttles, &throttle)
+		}
+
+		if err := admit(throttles); err != nil {
+			return err
+		}
+		for _, throttle := range throttles {
+			throttle.FailedCount++
+			throttle.LastFailedAt = now
+			if err := tx.Save(throttle).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *LoginThrottleRepository) Release(key string) error {
+	return r.DB.Model(&entity.LoginThrottle{}).
+		Where("throttle_key = ? AND failed_count > 0", key).
+		Update("failed_count", gorm.Expr("failed_count - 1")).Error
+}
+
+func (r *LoginThrottleRepository) RecordFailure(key string, now time.Time, window time.Duration) (*entity.LoginThrottle, error) {
+	var throttle entity.LoginThrottle
+	err := r.DB.Transaction(func(tx *gorm.DB) error {
+		// Make sure the row exists so concurrent failures serialize on it
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&entity.LoginThrottle{ThrottleKey: key, LastFailedAt: now}).Error; err != nil {
+			return err
+		}
+
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("throttle_key = ?", key).First(&throttle).Error
+		if err != nil {
+			return err
+		}
+
+		if now.Sub(throttle.LastFailedAt) > window {
+			throttle.FailedCount = 0
+		}
+		throttle.FailedCount++
+		throttle.LastFailedAt = now
+		return tx.Save(&throttle).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+func (r *LoginThrottleRepository) Lock(key string, until time.Time) error {
+	return r.DB.Model(&entity.LoginThrottle{}).Where("throttle_key = ?", key).Update("locked_until", until).Error
+}
+
+func (r *LoginThrottleRepository) Reset(key string) error {
+	return r.DB.Where("throttle_key = ?", key).Delete(&entity.LoginThrottle{}).Error
+}
+
+func (r *LoginThrottleRepository) DeleteExpired(now time.Time, window time.Duration) (int64, error) {
+	result := r.DB.Where("last_failed_at < ? AND (locked_until IS NULL OR locked_until <= ?)", now.Add(-window), now).
+		Delete(&entity.LoginThrottle{})
+	return result.RowsAffected, result.Error
+}
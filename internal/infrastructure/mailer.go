@@ -0,0 +1,115 @@
+package infrastructure
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/config"
+)
+
+type MailMessage struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers transactional email such as password reset links.
+type Mailer interface {
+	Send(msg MailMessage) error
+}
+
+// NewMailer builds the Mailer selected by cfg.Driver.
+func NewMailer(cfg config.MailConfig) (Mailer, error) {
+	switch cfg.Driver {
+	case "smtp":
+		return NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.From), nil
+	case "file":
+		return NewFileMailer(cfg.FileDir, cfg.From)
+	case "log":
+		log.Println("WARNING: MAIL_DRIVER=log writes outgoing mail, including secrets, to the log")
+		return NewLogMailer(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", cfg.Driver)
+	}
+}
+
+// SMTPMailer sends plain-text mail through an SMTP relay, authenticating
+// with PLAIN auth when a username is configured.
+type SMTPMailer struct {
+	addr     string
+	host     string
+	from     string
+	username string
+	password string
+}
+
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	return &SMTPMailer{
+		addr:     net.JoinHostPort(host, port),
+		host:     host,
+		from:     from,
+		username: username,
+		password: password,
+	}
+}
+
+func (m *SMTPMailer) Send(msg MailMessage) error {
+	var auth smtp.Auth
+	if m.username != "" {
+		auth = smtp.PlainAuth("", m.username, m.password, m.host)
+	}
+	return smtp.SendMail(m.addr, auth, m.from, []string{msg.To}, formatMessage(m.from, msg))
+}
+
+// LogMailer writes messages to the application log instead of sending them.
+// It is meant for local development only, as message bodies carry secrets.
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(msg MailMessage) error {
+	log.Printf("MAIL to=%s subject=%q\n%s", msg.To, msg.Subject, msg.Body)
+	return nil
+}
+
+// FileMailer stores every message as an .eml file in a directory, which lets
+// tests and local setups read the mail that would have been sent.
+type FileMailer struct {
+	dir  string
+	from string
+	seq  atomic.Uint64
+}
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+func (m *FileMailer) Send(msg MailMessage) error {
+	name := fmt.Sprintf("%d-%04d.eml", time.Now().UnixNano(), m.seq.Add(1))
+	return os.WriteFile(filepath.Join(m.dir, name), formatMessage(m.from, msg), 0o600)
+}
+
+func formatMessage(from string, msg MailMessage) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
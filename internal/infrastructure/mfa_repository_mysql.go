@@ -0,0 +1,76 @@
+package infrastructure
+
+import (
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"gorm.io/gorm"
+)
+
+type MFARepository struct {
+	DB *gorm.DB
+}
+
+func NewMFARepository(db *gorm.DB) *MFARepository {
+	return &MFARepository{DB: db}
+}
+
+func (r *MFARepository) FindTOTP(userID uint) (*entity.TOTPCredential, error) {
+	var credential entity.TOTPCredential
+	err := r.DB.Where("user_id = ?", userID).First(&credential).Error
+	return &credential, err
+}
+
+func (r *MFARepository) SaveTOTP(credential *entity.TOTPCredential) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", credential.UserID).Delete(&entity.TOTPCredential{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(credential).Error
+	})
+}
+
+func (r *MFARepository) ConfirmTOTP(id uint, step int64) error {
+	return r.DB.Model(&entity.TOTPCredential{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"confirmed_at": time.Now(), "last_used_step": step}).Error
+}
+
+func (r *MFARepository) AdvanceTOTPStep(id uint, step int64) (bool, error) {
+	result := r.DB.Model(&entity.TOTPCredential{}).
+		Where("id = ? AND last_used_step < ?", id, step).
+		Update("last_used_step", step)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *MFARepository) DeleteTOTP(userID uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&entity.TOTPCredential{}).Error
+	})
+}
+
+func (r *MFARepository) ReplaceRecoveryCodes(userID uint, codeHashes []string) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		codes := make([]*entity.RecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, &entity.RecoveryCode{UserID: userID, CodeHash: hash})
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(codes).Error
+	})
+}
+
+func (r *MFARepository) UseRecoveryCode(userID uint, codeHash string) (bool, error) {
+	result := r.DB.Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
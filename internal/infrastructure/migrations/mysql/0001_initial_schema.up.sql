@@ -0,0 +1,24 @@
+-- The users table as AutoMigrate created it before schema migrations. A
+-- database set up by those releases already has it and is adopted as it
+-- is; 0002 brings it up to date. For the same reason this migration has no
+-- down script and cannot be reverted: the table is never dropped.
+
+CREATE TABLE IF NOT EXISTS `users` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `username` varchar(30) NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `email` varchar(100),
+  `phone` varchar(20),
+  `mobile` varchar(20),
+  `image_url` varchar(255),
+  `password` varchar(255) NOT NULL,
+  `is_active` boolean DEFAULT true,
+  `role_id` bigint NOT NULL DEFAULT 1,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  `deleted_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_users_username` (`username`),
+  UNIQUE INDEX `idx_users_email` (`email`),
+  INDEX `idx_users_deleted_at` (`deleted_at`)
+);
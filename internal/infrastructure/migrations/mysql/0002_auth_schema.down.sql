@@ -0,0 +1,33 @@
+DROP TABLE IF EXISTS `external_identities`;
+DROP TABLE IF EXISTS `o_auth_authorization_codes`;
+DROP TABLE IF EXISTS `o_auth_clients`;
+DROP TABLE IF EXISTS `sessions`;
+DROP TABLE IF EXISTS `api_key_scopes`;
+DROP TABLE IF EXISTS `api_keys`;
+DROP TABLE IF EXISTS `password_histories`;
+DROP TABLE IF EXISTS `recovery_codes`;
+DROP TABLE IF EXISTS `totp_credentials`;
+DROP TABLE IF EXISTS `one_time_tokens`;
+DROP TABLE IF EXISTS `login_throttles`;
+DROP TABLE IF EXISTS `revoked_tokens`;
+DROP TABLE IF EXISTS `refresh_tokens`;
+DROP TABLE IF EXISTS `role_permissions`;
+DROP TABLE IF EXISTS `permissions`;
+DROP TABLE IF EXISTS `roles`;
+
+ALTER TABLE `users` ADD COLUMN `is_active` boolean DEFAULT true AFTER `password`;
+
+UPDATE `users` SET `is_active` = false WHERE `status` <> 'active';
+
+ALTER TABLE `users`
+  DROP INDEX `idx_users_status`,
+  DROP INDEX `idx_users_email_normalized`,
+  DROP INDEX `idx_users_username_normalized`,
+  DROP COLUMN `status_expires_at`,
+  DROP COLUMN `status_reason`,
+  DROP COLUMN `status`,
+  DROP COLUMN `password_change_required`,
+  DROP COLUMN `password_changed_at`,
+  DROP COLUMN `email_verified_at`,
+  DROP COLUMN `email_normalized`,
+  DROP COLUMN `username_normalized`;
@@ -0,0 +1,217 @@
+-- Brings the users table up to date and adds the tables of everything
+-- built on it. Deactivated users become suspended ones, and existing email
+-- addresses count as verified.
+
+ALTER TABLE `users`
+  ADD COLUMN `username_normalized` varchar(64) AFTER `email`,
+  ADD COLUMN `email_normalized` varchar(255) AFTER `username_normalized`,
+  ADD COLUMN `email_verified_at` datetime(3) NULL AFTER `email_normalized`,
+  ADD COLUMN `password_changed_at` datetime(3) NULL AFTER `password`,
+  ADD COLUMN `password_change_required` boolean NOT NULL DEFAULT false AFTER `password_changed_at`,
+  ADD COLUMN `status` varchar(16) NOT NULL DEFAULT 'active' AFTER `password_change_required`,
+  ADD COLUMN `status_reason` varchar(255) AFTER `status`,
+  ADD COLUMN `status_expires_at` datetime(3) NULL AFTER `status_reason`,
+  ADD UNIQUE INDEX `idx_users_username_normalized` (`username_normalized`),
+  ADD UNIQUE INDEX `idx_users_email_normalized` (`email_normalized`),
+  ADD INDEX `idx_users_status` (`status`);
+
+UPDATE `users` SET `status` = 'suspended', `status_reason` = 'Deactivated' WHERE `is_active` = false;
+
+UPDATE `users` SET `email_verified_at` = COALESCE(`created_at`, CURRENT_TIMESTAMP) WHERE `email` IS NOT NULL AND `email` <> '';
+
+ALTER TABLE `users` DROP COLUMN `is_active`;
+
+CREATE TABLE `roles` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `name` varchar(50) NOT NULL,
+  `description` varchar(255),
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_roles_name` (`name`)
+);
+
+CREATE TABLE `permissions` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `name` varchar(100) NOT NULL,
+  `description` varchar(255),
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_permissions_name` (`name`)
+);
+
+CREATE TABLE `role_permissions` (
+  `role_id` bigint unsigned,
+  `permission_id` bigint unsigned,
+  PRIMARY KEY (`role_id`, `permission_id`),
+  CONSTRAINT `fk_role_permissions_permission` FOREIGN KEY (`permission_id`) REFERENCES `permissions` (`id`),
+  CONSTRAINT `fk_role_permissions_role` FOREIGN KEY (`role_id`) REFERENCES `roles` (`id`)
+);
+
+CREATE TABLE `refresh_tokens` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `family_id` varchar(64) NOT NULL,
+  `token_hash` varchar(64) NOT NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `used_at` datetime(3) NULL,
+  `revoked_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_refresh_tokens_user_id` (`user_id`),
+  INDEX `idx_refresh_tokens_family_id` (`family_id`),
+  UNIQUE INDEX `idx_refresh_tokens_token_hash` (`token_hash`)
+);
+
+CREATE TABLE `revoked_tokens` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `jti` varchar(64),
+  `user_id` bigint unsigned NOT NULL,
+  `issued_before` datetime(3) NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_revoked_tokens_jti` (`jti`),
+  INDEX `idx_revoked_tokens_user_id` (`user_id`),
+  INDEX `idx_revoked_tokens_expires_at` (`expires_at`)
+);
+
+CREATE TABLE `login_throttles` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `throttle_key` varchar(191) NOT NULL,
+  `failed_count` bigint NOT NULL DEFAULT 0,
+  `last_failed_at` datetime(3) NULL,
+  `locked_until` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_login_throttles_throttle_key` (`throttle_key`)
+);
+
+CREATE TABLE `one_time_tokens` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `purpose` varchar(32) NOT NULL,
+  `token_hash` varchar(64) NOT NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `used_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_one_time_tokens_user_id` (`user_id`),
+  INDEX `idx_one_time_tokens_purpose` (`purpose`),
+  UNIQUE INDEX `idx_one_time_tokens_token_hash` (`token_hash`)
+);
+
+CREATE TABLE `totp_credentials` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `secret_ciphertext` varchar(255) NOT NULL,
+  `confirmed_at` datetime(3) NULL,
+  `last_used_step` bigint NOT NULL DEFAULT 0,
+  `created_at` datetime(3) NULL,
+  `updated_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_totp_credentials_user_id` (`user_id`)
+);
+
+CREATE TABLE `recovery_codes` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `code_hash` varchar(64) NOT NULL,
+  `used_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_recovery_codes_user_id` (`user_id`),
+  UNIQUE INDEX `idx_recovery_codes_code_hash` (`code_hash`)
+);
+
+CREATE TABLE `password_histories` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `password_hash` varchar(255) NOT NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_password_histories_user_id` (`user_id`)
+);
+
+CREATE TABLE `api_keys` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `name` varchar(100) NOT NULL,
+  `prefix` varchar(16) NOT NULL,
+  `secret_hash` varchar(64) NOT NULL,
+  `expires_at` datetime(3) NULL,
+  `last_used_at` datetime(3) NULL,
+  `revoked_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_api_keys_user_id` (`user_id`),
+  UNIQUE INDEX `idx_api_keys_prefix` (`prefix`)
+);
+
+CREATE TABLE `api_key_scopes` (
+  `api_key_id` bigint unsigned,
+  `permission_id` bigint unsigned,
+  PRIMARY KEY (`api_key_id`, `permission_id`),
+  CONSTRAINT `fk_api_key_scopes_api_key` FOREIGN KEY (`api_key_id`) REFERENCES `api_keys` (`id`),
+  CONSTRAINT `fk_api_key_scopes_permission` FOREIGN KEY (`permission_id`) REFERENCES `permissions` (`id`)
+);
+
+CREATE TABLE `sessions` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `family_id` varchar(64) NOT NULL,
+  `user_agent` varchar(255),
+  `ip_address` varchar(45),
+  `client_id` varchar(64),
+  `scope` varchar(1024),
+  `created_at` datetime(3) NULL,
+  `last_used_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_sessions_user_id` (`user_id`),
+  UNIQUE INDEX `idx_sessions_family_id` (`family_id`)
+);
+
+CREATE TABLE `o_auth_clients` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `client_id` varchar(64) NOT NULL,
+  `secret_hash` varchar(64),
+  `name` varchar(100) NOT NULL,
+  `redirect_uris` text,
+  `grant_types` text,
+  `scopes` text,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_o_auth_clients_client_id` (`client_id`)
+);
+
+CREATE TABLE `o_auth_authorization_codes` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `code_hash` varchar(64) NOT NULL,
+  `client_id` varchar(64) NOT NULL,
+  `user_id` bigint unsigned NOT NULL,
+  `family_id` varchar(64) NOT NULL,
+  `redirect_uri` text,
+  `scope` varchar(1024),
+  `nonce` varchar(255),
+  `code_challenge` varchar(128) NOT NULL,
+  `expires_at` datetime(3) NOT NULL,
+  `used_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE INDEX `idx_o_auth_authorization_codes_code_hash` (`code_hash`),
+  INDEX `idx_o_auth_authorization_codes_client_id` (`client_id`),
+  INDEX `idx_o_auth_authorization_codes_user_id` (`user_id`)
+);
+
+CREATE TABLE `external_identities` (
+  `id` bigint unsigned AUTO_INCREMENT,
+  `user_id` bigint unsigned NOT NULL,
+  `provider` varchar(64) NOT NULL,
+  `subject` varchar(255) NOT NULL,
+  `email` varchar(255),
+  `last_login_at` datetime(3) NULL,
+  `created_at` datetime(3) NULL,
+  PRIMARY KEY (`id`),
+  INDEX `idx_external_identities_user_id` (`user_id`),
+  UNIQUE INDEX `idx_external_identities_subject` (`provider`, `subject`)
+);
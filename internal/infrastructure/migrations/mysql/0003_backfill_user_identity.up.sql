@@ -0,0 +1,2 @@
+-- Normalizing names takes Unicode case folding, which is done in Go; see
+-- backfillUserIdentity.
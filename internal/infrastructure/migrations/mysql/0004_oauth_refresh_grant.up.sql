@@ -0,0 +1,7 @@
+-- Refresh tokens used to come with the authorization code grant and now
+-- need a refresh_token grant of their own. Clients registered before keep
+-- getting them.
+
+UPDATE `o_auth_clients`
+SET `grant_types` = REPLACE(`grant_types`, '"authorization_code"', '"authorization_code","refresh_token"')
+WHERE `grant_types` LIKE '%"authorization_code"%' AND `grant_types` NOT LIKE '%"refresh_token"%';
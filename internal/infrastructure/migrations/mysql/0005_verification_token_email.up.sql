@@ -0,0 +1,5 @@
+-- Email verification tokens remember the address they were sent to, which
+-- is the one they verify. Tokens sent before have none and are no longer
+-- accepted; users can ask for a new one.
+
+ALTER TABLE `one_time_tokens` ADD COLUMN `email` varchar(100) NULL;
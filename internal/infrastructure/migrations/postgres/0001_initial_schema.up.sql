@@ -0,0 +1,23 @@
+-- The users table of the baseline schema, matching that of
+-- mysql/0001_initial_schema.up.sql. Like that one it has no down script
+-- and cannot be reverted, as the table may predate schema migrations.
+
+CREATE TABLE IF NOT EXISTS "users" (
+  "id" bigserial,
+  "username" varchar(30) NOT NULL,
+  "name" varchar(100) NOT NULL,
+  "email" varchar(100),
+  "phone" varchar(20),
+  "mobile" varchar(20),
+  "image_url" varchar(255),
+  "password" varchar(255) NOT NULL,
+  "is_active" boolean DEFAULT true,
+  "role_id" bigint NOT NULL DEFAULT 1,
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  "deleted_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_username" ON "users" ("username");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_email" ON "users" ("email");
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
@@ -0,0 +1,215 @@
+-- Matches mysql/0002_auth_schema.up.sql.
+
+ALTER TABLE "users"
+  ADD COLUMN "username_normalized" varchar(64),
+  ADD COLUMN "email_normalized" varchar(255),
+  ADD COLUMN "email_verified_at" timestamptz,
+  ADD COLUMN "password_changed_at" timestamptz,
+  ADD COLUMN "password_change_required" boolean NOT NULL DEFAULT false,
+  ADD COLUMN "status" varchar(16) NOT NULL DEFAULT 'active',
+  ADD COLUMN "status_reason" varchar(255),
+  ADD COLUMN "status_expires_at" timestamptz;
+CREATE UNIQUE INDEX "idx_users_username_normalized" ON "users" ("username_normalized");
+CREATE UNIQUE INDEX "idx_users_email_normalized" ON "users" ("email_normalized");
+CREATE INDEX "idx_users_status" ON "users" ("status");
+
+UPDATE "users" SET "status" = 'suspended', "status_reason" = 'Deactivated' WHERE "is_active" = false;
+
+UPDATE "users" SET "email_verified_at" = COALESCE("created_at", CURRENT_TIMESTAMP) WHERE "email" IS NOT NULL AND "email" <> '';
+
+ALTER TABLE "users" DROP COLUMN "is_active";
+
+CREATE TABLE "roles" (
+  "id" bigserial,
+  "name" varchar(50) NOT NULL,
+  "description" varchar(255),
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_roles_name" ON "roles" ("name");
+
+CREATE TABLE "permissions" (
+  "id" bigserial,
+  "name" varchar(100) NOT NULL,
+  "description" varchar(255),
+  PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_permissions_name" ON "permissions" ("name");
+
+CREATE TABLE "role_permissions" (
+  "role_id" bigint,
+  "permission_id" bigint,
+  PRIMARY KEY ("role_id", "permission_id"),
+  CONSTRAINT "fk_role_permissions_permission" FOREIGN KEY ("permission_id") REFERENCES "permissions" ("id"),
+  CONSTRAINT "fk_role_permissions_role" FOREIGN KEY ("role_id") REFERENCES "roles" ("id")
+);
+
+CREATE TABLE "refresh_tokens" (
+  "id" bigserial,
+  "user_id" bigint NOT NULL,
+  "family_id" varchar(64) NOT NULL,
+  "token_hash" varchar(64) NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "used_at" timestamptz,
+  "revoked_at" timestamptz,
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_refresh_tokens_user_id" ON "refresh_tokens" ("user_id");
+CREATE INDEX "idx_refresh_tokens_family_id" ON "refresh_tokens" ("family_id");
+CREATE UNIQUE INDEX "idx_refresh_tokens_token_hash" ON "refresh_tokens" ("token_hash");
+
+CREATE TABLE "revoked_tokens" (
+  "id" bigserial,
+  "jti" varchar(64),
+  "user_id" bigint NOT NULL,
+  "issued_before" timestamptz,
+  "expires_at" timestamptz NOT NULL,
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_revoked_tokens_jti" ON "revoked_tokens" ("jti");
+CREATE INDEX "idx_revoked_tokens_user_id" ON "revoked_tokens" ("user_id");
+CREATE INDEX "idx_revoked_tokens_expires_at" ON "revoked_tokens" ("expires_at");
+
+CREATE TABLE "login_throttles" (
+  "id" bigserial,
+  "throttle_key" varchar(191) NOT NULL,
+  "failed_count" bigint NOT NULL DEFAULT 0,
+  "last_failed_at" timestamptz,
+  "locked_until" timestamptz,
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_login_throttles_throttle_key" ON "login_throttles" ("throttle_key");
+
+CREATE TABLE "one_time_tokens" (
+  "id" bigserial,
+  "user_id" bigint NOT NULL,
+  "purpose" varchar(32) NOT NULL,
+  "token_hash" varchar(64) NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_one_time_tokens_user_id" ON "one_time_tokens" ("user_id");
+CREATE INDEX "idx_one_time_tokens_purpose" ON "one_time_tokens" ("purpose");
+CREATE UNIQUE INDEX "idx_one_time_tokens_token_hash" ON "one_time_tokens" ("token_hash");
+
+CREATE TABLE "totp_credentials" (
+  "id" bigserial,
+  "user_id" bigint NOT NULL,
+  "secret_ciphertext" varchar(255) NOT NULL,
+  "confirmed_at" timestamptz,
+  "last_used_step" bigint NOT NULL DEFAULT 0,
+  "created_at" timestamptz,
+  "updated_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_totp_credentials_user_id" ON "totp_credentials" ("user_id");
+
+CREATE TABLE "recovery_codes" (
+  "id" bigserial,
+  "user_id" bigint NOT NULL,
+  "code_hash" varchar(64) NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_recovery_codes_user_id" ON "recovery_codes" ("user_id");
+CREATE UNIQUE INDEX "idx_recovery_codes_code_hash" ON "recovery_codes" ("code_hash");
+
+CREATE TABLE "password_histories" (
+  "id" bigserial,
+  "user_id" bigint NOT NULL,
+  "password_hash" varchar(255) NOT NULL,
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_password_histories_user_id" ON "password_histories" ("user_id");
+
+CREATE TABLE "api_keys" (
+  "id" bigserial,
+  "user_id" bigint NOT NULL,
+  "name" varchar(100) NOT NULL,
+  "prefix" varchar(16) NOT NULL,
+  "secret_hash" varchar(64) NOT NULL,
+  "expires_at" timestamptz,
+  "last_used_at" timestamptz,
+  "revoked_at" timestamptz,
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_api_keys_user_id" ON "api_keys" ("user_id");
+CREATE UNIQUE INDEX "idx_api_keys_prefix" ON "api_keys" ("prefix");
+
+CREATE TABLE "api_key_scopes" (
+  "api_key_id" bigint,
+  "permission_id" bigint,
+  PRIMARY KEY ("api_key_id", "permission_id"),
+  CONSTRAINT "fk_api_key_scopes_api_key" FOREIGN KEY ("api_key_id") REFERENCES "api_keys" ("id"),
+  CONSTRAINT "fk_api_key_scopes_permission" FOREIGN KEY ("permission_id") REFERENCES "permissions" ("id")
+);
+
+CREATE TABLE "sessions" (
+  "id" bigserial,
+  "user_id" bigint NOT NULL,
+  "family_id" varchar(64) NOT NULL,
+  "user_agent" varchar(255),
+  "ip_address" varchar(45),
+  "client_id" varchar(64),
+  "scope" varchar(1024),
+  "created_at" timestamptz,
+  "last_used_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_sessions_user_id" ON "sessions" ("user_id");
+CREATE UNIQUE INDEX "idx_sessions_family_id" ON "sessions" ("family_id");
+
+CREATE TABLE "o_auth_clients" (
+  "id" bigserial,
+  "client_id" varchar(64) NOT NULL,
+  "secret_hash" varchar(64),
+  "name" varchar(100) NOT NULL,
+  "redirect_uris" text,
+  "grant_types" text,
+  "scopes" text,
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_o_auth_clients_client_id" ON "o_auth_clients" ("client_id");
+
+CREATE TABLE "o_auth_authorization_codes" (
+  "id" bigserial,
+  "code_hash" varchar(64) NOT NULL,
+  "client_id" varchar(64) NOT NULL,
+  "user_id" bigint NOT NULL,
+  "family_id" varchar(64) NOT NULL,
+  "redirect_uri" text,
+  "scope" varchar(1024),
+  "nonce" varchar(255),
+  "code_challenge" varchar(128) NOT NULL,
+  "expires_at" timestamptz NOT NULL,
+  "used_at" timestamptz,
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_o_auth_authorization_codes_code_hash" ON "o_auth_authorization_codes" ("code_hash");
+CREATE INDEX "idx_o_auth_authorization_codes_client_id" ON "o_auth_authorization_codes" ("client_id");
+CREATE INDEX "idx_o_auth_authorization_codes_user_id" ON "o_auth_authorization_codes" ("user_id");
+
+CREATE TABLE "external_identities" (
+  "id" bigserial,
+  "user_id" bigint NOT NULL,
+  "provider" varchar(64) NOT NULL,
+  "subject" varchar(255) NOT NULL,
+  "email" varchar(255),
+  "last_login_at" timestamptz,
+  "created_at" timestamptz,
+  PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_external_identities_user_id" ON "external_identities" ("user_id");
+CREATE UNIQUE INDEX "idx_external_identities_subject" ON "external_identities" ("provider", "subject");
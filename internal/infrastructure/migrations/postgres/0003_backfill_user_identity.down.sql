@@ -0,0 +1 @@
+UPDATE "users" SET "username_normalized" = NULL, "email_normalized" = NULL;
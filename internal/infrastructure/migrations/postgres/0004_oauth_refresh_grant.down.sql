@@ -0,0 +1,2 @@
+UPDATE "o_auth_clients"
+SET "grant_types" = REPLACE("grant_types", '"authorization_code","refresh_token"', '"authorization_code"');
@@ -0,0 +1,5 @@
+-- Matches mysql/0004_oauth_refresh_grant.up.sql.
+
+UPDATE "o_auth_clients"
+SET "grant_types" = REPLACE("grant_types", '"authorization_code"', '"authorization_code","refresh_token"')
+WHERE "grant_types" LIKE '%"authorization_code"%' AND "grant_types" NOT LIKE '%"refresh_token"%';
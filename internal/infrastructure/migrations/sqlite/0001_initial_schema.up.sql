@@ -0,0 +1,22 @@
+-- The users table of the baseline schema, matching that of
+-- mysql/0001_initial_schema.up.sql. Like that one it has no down script
+-- and cannot be reverted, as the table may predate schema migrations.
+
+CREATE TABLE IF NOT EXISTS `users` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `username` text NOT NULL,
+  `name` text NOT NULL,
+  `email` text,
+  `phone` text,
+  `mobile` text,
+  `image_url` text,
+  `password` text NOT NULL,
+  `is_active` numeric DEFAULT true,
+  `role_id` integer NOT NULL DEFAULT 1,
+  `created_at` datetime,
+  `updated_at` datetime,
+  `deleted_at` datetime
+);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_users_username` ON `users` (`username`);
+CREATE UNIQUE INDEX IF NOT EXISTS `idx_users_email` ON `users` (`email`);
+CREATE INDEX IF NOT EXISTS `idx_users_deleted_at` ON `users` (`deleted_at`);
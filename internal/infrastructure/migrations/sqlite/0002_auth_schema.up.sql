@@ -0,0 +1,200 @@
+-- Matches mysql/0002_auth_schema.up.sql. SQLite adds one column at a time.
+
+ALTER TABLE `users` ADD COLUMN `username_normalized` text;
+ALTER TABLE `users` ADD COLUMN `email_normalized` text;
+ALTER TABLE `users` ADD COLUMN `email_verified_at` datetime;
+ALTER TABLE `users` ADD COLUMN `password_changed_at` datetime;
+ALTER TABLE `users` ADD COLUMN `password_change_required` numeric NOT NULL DEFAULT false;
+ALTER TABLE `users` ADD COLUMN `status` text NOT NULL DEFAULT 'active';
+ALTER TABLE `users` ADD COLUMN `status_reason` text;
+ALTER TABLE `users` ADD COLUMN `status_expires_at` datetime;
+CREATE UNIQUE INDEX `idx_users_username_normalized` ON `users` (`username_normalized`);
+CREATE UNIQUE INDEX `idx_users_email_normalized` ON `users` (`email_normalized`);
+CREATE INDEX `idx_users_status` ON `users` (`status`);
+
+UPDATE `users` SET `status` = 'suspended', `status_reason` = 'Deactivated' WHERE `is_active` = false;
+
+UPDATE `users` SET `email_verified_at` = COALESCE(`created_at`, CURRENT_TIMESTAMP) WHERE `email` IS NOT NULL AND `email` <> '';
+
+ALTER TABLE `users` DROP COLUMN `is_active`;
+
+CREATE TABLE `roles` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `name` text NOT NULL,
+  `description` text,
+  `created_at` datetime,
+  `updated_at` datetime
+);
+CREATE UNIQUE INDEX `idx_roles_name` ON `roles` (`name`);
+
+CREATE TABLE `permissions` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `name` text NOT NULL,
+  `description` text
+);
+CREATE UNIQUE INDEX `idx_permissions_name` ON `permissions` (`name`);
+
+CREATE TABLE `role_permissions` (
+  `role_id` integer,
+  `permission_id` integer,
+  PRIMARY KEY (`role_id`, `permission_id`),
+  CONSTRAINT `fk_role_permissions_permission` FOREIGN KEY (`permission_id`) REFERENCES `permissions` (`id`),
+  CONSTRAINT `fk_role_permissions_role` FOREIGN KEY (`role_id`) REFERENCES `roles` (`id`)
+);
+
+CREATE TABLE `refresh_tokens` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` integer NOT NULL,
+  `family_id` text NOT NULL,
+  `token_hash` text NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `used_at` datetime,
+  `revoked_at` datetime,
+  `created_at` datetime
+);
+CREATE INDEX `idx_refresh_tokens_user_id` ON `refresh_tokens` (`user_id`);
+CREATE INDEX `idx_refresh_tokens_family_id` ON `refresh_tokens` (`family_id`);
+CREATE UNIQUE INDEX `idx_refresh_tokens_token_hash` ON `refresh_tokens` (`token_hash`);
+
+CREATE TABLE `revoked_tokens` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `jti` text,
+  `user_id` integer NOT NULL,
+  `issued_before` datetime,
+  `expires_at` datetime NOT NULL,
+  `created_at` datetime
+);
+CREATE INDEX `idx_revoked_tokens_jti` ON `revoked_tokens` (`jti`);
+CREATE INDEX `idx_revoked_tokens_user_id` ON `revoked_tokens` (`user_id`);
+CREATE INDEX `idx_revoked_tokens_expires_at` ON `revoked_tokens` (`expires_at`);
+
+CREATE TABLE `login_throttles` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `throttle_key` text NOT NULL,
+  `failed_count` integer NOT NULL DEFAULT 0,
+  `last_failed_at` datetime,
+  `locked_until` datetime,
+  `created_at` datetime,
+  `updated_at` datetime
+);
+CREATE UNIQUE INDEX `idx_login_throttles_throttle_key` ON `login_throttles` (`throttle_key`);
+
+CREATE TABLE `one_time_tokens` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` integer NOT NULL,
+  `purpose` text NOT NULL,
+  `token_hash` text NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `used_at` datetime,
+  `created_at` datetime
+);
+CREATE INDEX `idx_one_time_tokens_user_id` ON `one_time_tokens` (`user_id`);
+CREATE INDEX `idx_one_time_tokens_purpose` ON `one_time_tokens` (`purpose`);
+CREATE UNIQUE INDEX `idx_one_time_tokens_token_hash` ON `one_time_tokens` (`token_hash`);
+
+CREATE TABLE `totp_credentials` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` integer NOT NULL,
+  `secret_ciphertext` text NOT NULL,
+  `confirmed_at` datetime,
+  `last_used_step` integer NOT NULL DEFAULT 0,
+  `created_at` datetime,
+  `updated_at` datetime
+);
+CREATE UNIQUE INDEX `idx_totp_credentials_user_id` ON `totp_credentials` (`user_id`);
+
+CREATE TABLE `recovery_codes` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` integer NOT NULL,
+  `code_hash` text NOT NULL,
+  `used_at` datetime,
+  `created_at` datetime
+);
+CREATE INDEX `idx_recovery_codes_user_id` ON `recovery_codes` (`user_id`);
+CREATE UNIQUE INDEX `idx_recovery_codes_code_hash` ON `recovery_codes` (`code_hash`);
+
+CREATE TABLE `password_histories` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` integer NOT NULL,
+  `password_hash` text NOT NULL,
+  `created_at` datetime
+);
+CREATE INDEX `idx_password_histories_user_id` ON `password_histories` (`user_id`);
+
+CREATE TABLE `api_keys` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` integer NOT NULL,
+  `name` text NOT NULL,
+  `prefix` text NOT NULL,
+  `secret_hash` text NOT NULL,
+  `expires_at` datetime,
+  `last_used_at` datetime,
+  `revoked_at` datetime,
+  `created_at` datetime
+);
+CREATE INDEX `idx_api_keys_user_id` ON `api_keys` (`user_id`);
+CREATE UNIQUE INDEX `idx_api_keys_prefix` ON `api_keys` (`prefix`);
+
+CREATE TABLE `api_key_scopes` (
+  `api_key_id` integer,
+  `permission_id` integer,
+  PRIMARY KEY (`api_key_id`, `permission_id`),
+  CONSTRAINT `fk_api_key_scopes_api_key` FOREIGN KEY (`api_key_id`) REFERENCES `api_keys` (`id`),
+  CONSTRAINT `fk_api_key_scopes_permission` FOREIGN KEY (`permission_id`) REFERENCES `permissions` (`id`)
+);
+
+CREATE TABLE `sessions` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` integer NOT NULL,
+  `family_id` text NOT NULL,
+  `user_agent` text,
+  `ip_address` text,
+  `client_id` text,
+  `scope` text,
+  `created_at` datetime,
+  `last_used_at` datetime
+);
+CREATE INDEX `idx_sessions_user_id` ON `sessions` (`user_id`);
+CREATE UNIQUE INDEX `idx_sessions_family_id` ON `sessions` (`family_id`);
+
+CREATE TABLE `o_auth_clients` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `client_id` text NOT NULL,
+  `secret_hash` text,
+  `name` text NOT NULL,
+  `redirect_uris` text,
+  `grant_types` text,
+  `scopes` text,
+  `created_at` datetime
+);
+CREATE UNIQUE INDEX `idx_o_auth_clients_client_id` ON `o_auth_clients` (`client_id`);
+
+CREATE TABLE `o_auth_authorization_codes` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `code_hash` text NOT NULL,
+  `client_id` text NOT NULL,
+  `user_id` integer NOT NULL,
+  `family_id` text NOT NULL,
+  `redirect_uri` text,
+  `scope` text,
+  `nonce` text,
+  `code_challenge` text NOT NULL,
+  `expires_at` datetime NOT NULL,
+  `used_at` datetime,
+  `created_at` datetime
+);
+CREATE UNIQUE INDEX `idx_o_auth_authorization_codes_code_hash` ON `o_auth_authorization_codes` (`code_hash`);
+CREATE INDEX `idx_o_auth_authorization_codes_client_id` ON `o_auth_authorization_codes` (`client_id`);
+CREATE INDEX `idx_o_auth_authorization_codes_user_id` ON `o_auth_authorization_codes` (`user_id`);
+
+CREATE TABLE `external_identities` (
+  `id` integer PRIMARY KEY AUTOINCREMENT,
+  `user_id` integer NOT NULL,
+  `provider` text NOT NULL,
+  `subject` text NOT NULL,
+  `email` text,
+  `last_login_at` datetime,
+  `created_at` datetime
+);
+CREATE INDEX `idx_external_identities_user_id` ON `external_identities` (`user_id`);
+CREATE UNIQUE INDEX `idx_external_identities_subject` ON `external_identities` (`provider`, `subject`);
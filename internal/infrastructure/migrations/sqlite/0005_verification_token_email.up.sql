@@ -0,0 +1,3 @@
+-- Matches mysql/0005_verification_token_email.up.sql.
+
+ALTER TABLE `one_time_tokens` ADD COLUMN `email` text;
@@ -0,0 +1,440 @@
+package infrastructure
+
+import (
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+//go:embed migrations
+var migrationFiles embed.FS
+
+var (
+	// ErrSchemaOutdated is returned when migrations of this build have not
+	// been applied to the database yet.
+	ErrSchemaOutdated = errors.New("database schema is out of date")
+	// ErrSchemaTooNew is returned when the database has migrations applied
+	// that this build does not know, i.e. it was migrated by a newer one.
+	ErrSchemaTooNew = errors.New("database schema is newer than this build")
+)
+
+// migrationLockName names the advisory lock held while migrating, so that
+// replicas starting together don't apply the same migration twice.
+const migrationLockName = "go-grpc-starter.schema_migrations"
+
+// migrationLockTimeout is how long to wait for another process to finish
+// migrating.
+const migrationLockTimeout = 5 * time.Minute
+
+var errMigrationLockTimeout = errors.New("timed out waiting for another process to finish migrating")
+
+// ErrIrreversibleMigration is returned when reverting a migration that has
+// no down script.
+var ErrIrreversibleMigration = errors.New("migration cannot be reverted")
+
+// migrationSteps are the parts of migrations that cannot be written in SQL,
+// by version. Each runs after the up script of its migration, in the same
+// transaction where there is one.
+var migrationSteps = map[int64]func(tx *gorm.DB) error{
+	3: backfillUserIdentity,
+}
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a versioned schema change with the scripts that apply and
+// revert it.
+type Migration struct {
+	Version int64
+	Name    string
+	up      string
+	down    string
+	step    func(tx *gorm.DB) error
+}
+
+// MigrationStatus is a migration with the time it was applied, if it was.
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	// Unknown marks migrations applied to the database that this build
+	// doesn't have.
+	Unknown bool
+}
+
+// schemaMigration records an applied migration.
+type schemaMigration struct {
+	Version   int64     `gorm:"primaryKey;autoIncrement:false"`
+	Name      string    `gorm:"size:255;not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator applies the SQL migrations embedded in the binary, found in
+// migrations/<dialect> as NNNN_name.up.sql and NNNN_name.down.sql, and
+// records them in the schema_migrations table. A migration without a down
+// script cannot be reverted.
+//
+// On PostgreSQL and SQLite each migration runs in a transaction. MySQL
+// commits DDL implicitly, so there a migration that fails halfway is not
+// rolled back and is not recorded, and running it again fails on the
+// statements that did apply, such as the ADD COLUMNs of 0002_auth_schema.
+// Recover by hand once the cause is fixed: either undo the applied
+// statements, with the down script as a guide, and migrate again, or apply
+// the remaining statements and insert the version into schema_migrations.
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+func loadMigrations(dialect string) ([]Migration, error) {
+	dir := path.Join("migrations", dialect)
+	entries, err := fs.ReadDir(migrationFiles, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for %s databases", dialect)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("unexpected migration file %s", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid migration version in %s", entry.Name())
+		}
+		script, err := fs.ReadFile(migrationFiles, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migration %d is named both %s and %s", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.up = string(script)
+		} else {
+			// Leaving the file out is how a migration says it is irreversible
+			if len(splitStatements(string(script))) == 0 {
+				return nil, fmt.Errorf("migration %d %s has a down script without statements", version, m.Name)
+			}
+			m.down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == "" {
+			return nil, fmt.Errorf("migration %d %s lacks an up script", m.Version, m.Name)
+		}
+		m.step = migrationSteps[m.Version]
+		migrations = append(migrations, *m)
+	}
+	for version := range migrationSteps {
+		if _, ok := byVersion[version]; !ok {
+			return nil, fmt.Errorf("migration %d has a step but no scripts", version)
+		}
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Latest returns the version the schema of this build is at, i.e. that of
+// its last migration.
+func (m *Migrator) Latest() int64 {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Up applies up to steps pending migrations in order, all of them when
+// steps is not positive, and returns those it applied.
+func (m *Migrator) Up(steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+		if unknown := m.unknown(applied); len(unknown) > 0 {
+			return fmt.Errorf("%w: version %d is not known", ErrSchemaTooNew, unknown[len(unknown)-1].Version)
+		}
+		for _, migration := range m.migrations {
+			if steps > 0 && len(done) == steps {
+				break
+			}
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			err := m.apply(conn, migration, migration.up, migration.step, func(tx *gorm.DB) error {
+				return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+			})
+			if err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Down reverts the last steps applied migrations, newest first, and returns
+// those it reverted.
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	var done []Migration
+	err := m.locked(func(conn *gorm.DB) error {
+		applied, err := m.applied(conn)
+		if err != nil {
+			return err
+		}
+		if unknown := m.unknown(applied); len(unknown) > 0 {
+			return fmt.Errorf("%w: version %d can only be reverted by the build that has it", ErrSchemaTooNew, unknown[len(unknown)-1].Version)
+		}
+		for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if migration.down == "" {
+				return fmt.Errorf("%w: %d %s", ErrIrreversibleMigration, migration.Version, migration.Name)
+			}
+			err := m.apply(conn, migration, migration.down, nil, func(tx *gorm.DB) error {
+				return tx.Delete(&schemaMigration{}, migration.Version).Error
+			})
+			if err != nil {
+				return err
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// Status lists the migrations of this build and when they were applied,
+// followed by any applied migrations the build doesn't know.
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	applied, err := m.applied(m.db)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if record, ok := applied[migration.Version]; ok {
+			appliedAt := record.AppliedAt
+			status.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range m.unknown(applied) {
+		appliedAt := record.AppliedAt
+		statuses = append(statuses, MigrationStatus{Version: record.Version, Name: record.Name, AppliedAt: &appliedAt, Unknown: true})
+	}
+	return statuses, nil
+}
+
+// Check returns ErrSchemaOutdated or ErrSchemaTooNew unless exactly the
+// migrations of this build have been applied.
+func (m *Migrator) Check() error {
+	applied, err := m.applied(m.db)
+	if err != nil {
+		return err
+	}
+	if unknown := m.unknown(applied); len(unknown) > 0 {
+		return fmt.Errorf("%w: database is at version %d, this build expects %d", ErrSchemaTooNew, unknown[len(unknown)-1].Version, m.Latest())
+	}
+	var pending []string
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, fmt.Sprintf("%d_%s", migration.Version, migration.Name))
+		}
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%w: %s not applied", ErrSchemaOutdated, strings.Join(pending, ", "))
+	}
+	return nil
+}
+
+// applied returns the applied migrations by version. A database without
+// the schema_migrations table has none.
+func (m *Migrator) applied(db *gorm.DB) (map[int64]schemaMigration, error) {
+	applied := make(map[int64]schemaMigration)
+	if !db.Migrator().HasTable(&schemaMigration{}) {
+		return applied, nil
+	}
+	var records []schemaMigration
+	if err := db.Order("version").Find(&records).Error; err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		applied[record.Version] = record
+	}
+	return applied, nil
+}
+
+// unknown returns the applied migrations this build doesn't have, oldest
+// first.
+func (m *Migrator) unknown(applied map[int64]schemaMigration) []schemaMigration {
+	known := make(map[int64]bool, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = true
+	}
+	var unknown []schemaMigration
+	for version, record := range applied {
+		if !known[version] {
+			unknown = append(unknown, record)
+		}
+	}
+	sort.Slice(unknown, func(i, j int) bool { return unknown[i].Version < unknown[j].Version })
+	return unknown
+}
+
+// apply runs script and step, if any, and records that it did with record,
+// together in a transaction where DDL is transactional.
+func (m *Migrator) apply(conn *gorm.DB, migration Migration, script string, step func(tx *gorm.DB) error, record func(tx *gorm.DB) error) error {
+	if conn.Dialector.Name() == "mysql" {
+		if err := m.run(conn, migration, script, step); err != nil {
+			return err
+		}
+		return record(conn)
+	}
+	return conn.Transaction(func(tx *gorm.DB) error {
+		if err := m.run(tx, migration, script, step); err != nil {
+			return err
+		}
+		return record(tx)
+	})
+}
+
+func (m *Migrator) run(conn *gorm.DB, migration Migration, script string, step func(tx *gorm.DB) error) error {
+	for _, statement := range splitStatements(script) {
+		if err := conn.Exec(statement).Error; err != nil {
+			return fmt.Errorf("migration %d %s: %w", migration.Version, migration.Name, err)
+		}
+	}
+	if step != nil {
+		if err := step(conn); err != nil {
+			return fmt.Errorf("migration %d %s: %w", migration.Version, migration.Name, err)
+		}
+	}
+	return nil
+}
+
+// locked runs fn on a single connection holding the migration lock, with
+// the schema_migrations table in place.
+func (m *Migrator) locked(fn func(conn *gorm.DB) error) error {
+	return m.db.Connection(func(conn *gorm.DB) error {
+		// Statements on conn would otherwise pile up each other's conditions
+		conn = conn.Session(&gorm.Session{NewDB: true})
+		release, err := acquireMigrationLock(conn)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		if !conn.Migrator().HasTable(&schemaMigration{}) {
+			if err := conn.Migrator().CreateTable(&schemaMigration{}); err != nil {
+				return err
+			}
+		}
+		return fn(conn)
+	})
+}
+
+// acquireMigrationLock takes the advisory lock on conn, which must stay
+// the same connection until the returned function releases it.
+func acquireMigrationLock(conn *gorm.DB) (func(), error) {
+	switch conn.Dialector.Name() {
+	case "mysql":
+		var acquired sql.NullInt64
+		if err := conn.Raw("SELECT GET_LOCK(?, ?)", migrationLockName, int(migrationLockTimeout.Seconds())).Scan(&acquired).Error; err != nil {
+			return nil, err
+		}
+		if !acquired.Valid || acquired.Int64 != 1 {
+			return nil, errMigrationLockTimeout
+		}
+		return func() {
+			var released sql.NullInt64
+			conn.Raw("SELECT RELEASE_LOCK(?)", migrationLockName).Scan(&released)
+		}, nil
+	case "postgres":
+		// Advisory locks are named by number there
+		hash := fnv.New64a()
+		hash.Write([]byte(migrationLockName))
+		key := int64(hash.Sum64())
+		deadline := time.Now().Add(migrationLockTimeout)
+		for {
+			var acquired bool
+			if err := conn.Raw("SELECT pg_try_advisory_lock(?)", key).Scan(&acquired).Error; err != nil {
+				return nil, err
+			}
+			if acquired {
+				break
+			}
+			if time.Now().After(deadline) {
+				return nil, errMigrationLockTimeout
+			}
+			time.Sleep(time.Second)
+		}
+		return func() {
+			var released bool
+			conn.Raw("SELECT pg_advisory_unlock(?)", key).Scan(&released)
+		}, nil
+	default:
+		// SQLite serializes writers by itself, and its migrations run in a
+		// transaction
+		return func() {}, nil
+	}
+}
+
+// splitStatements splits a script into statements at semicolons that end
+// a line, dropping comment lines.
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
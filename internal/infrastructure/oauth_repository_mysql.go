@@ -0,0 +1,59 @@
+package infrastructure
+
+import (
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"gorm.io/gorm"
+)
+
+type OAuthRepository struct {
+	DB *gorm.DB
+}
+
+func NewOAuthRepository(db *gorm.DB) *OAuthRepository {
+	return &OAuthRepository{DB: db}
+}
+
+func (r *OAuthRepository) CreateClient(client *entity.OAuthClient) error {
+	return r.DB.Create(client).Error
+}
+
+func (r *OAuthRepository) FindClient(clientID string) (*entity.OAuthClient, error) {
+	var client entity.OAuthClient
+	err := r.DB.Where("client_id = ?", clientID).First(&client).Error
+	return &client, err
+}
+
+func (r *OAuthRepository) FindClientByID(id uint) (*entity.OAuthClient, error) {
+	var client entity.OAuthClient
+	err := r.DB.First(&client, id).Error
+	return &client, err
+}
+
+func (r *OAuthRepository) ListClients() ([]*entity.OAuthClient, error) {
+	var clients []*entity.OAuthClient
+	err := r.DB.Order("id ASC").Find(&clients).Error
+	return clients, err
+}
+
+func (r *OAuthRepository) DeleteClient(id uint) error {
+	return r.DB.Delete(&entity.OAuthClient{}, id).Error
+}
+
+func (r *OAuthRepository) CreateCode(code *entity.OAuthAuthorizationCode) error {
+	return r.DB.Create(code).Error
+}
+
+func (r *OAuthRepository) FindCode(codeHash string) (*entity.OAuthAuthorizationCode, error) {
+	var code entity.OAuthAuthorizationCode
+	err := r.DB.Where("code_hash = ?", codeHash).First(&code).Error
+	return &code, err
+}
+
+func (r *OAuthRepository) MarkCodeUsed(id uint) (bool, error) {
+	result := r.DB.Model(&entity.OAuthAuthorizationCode{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
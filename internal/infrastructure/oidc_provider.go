@@ -0,0 +1,295 @@
+package infrastructure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrOIDCRejected is returned when a provider refuses the authorization code
+// or its ID token fails verification, as opposed to the provider being
+// unreachable.
+var ErrOIDCRejected = errors.New("oidc login rejected")
+
+var errOIDCKeysUnavailable = errors.New("failed to fetch signing keys")
+
+// oidcKeyRefreshInterval limits how often the JWKS is fetched again for an
+// unknown key ID, so tokens with made-up key IDs cannot hammer the provider.
+const oidcKeyRefreshInterval = time.Minute
+
+// oidcClockSkew is tolerated between the provider's clock and ours.
+const oidcClockSkew = time.Minute
+
+// oidcSigningMethods are the algorithms accepted on ID tokens. Symmetric
+// ones would need the client secret as the key and are not supported.
+var oidcSigningMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// OIDCIdentity is the verified user an ID token describes.
+type OIDCIdentity struct {
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+// OIDCProvider signs users in through an external OpenID Connect provider
+// with the authorization code flow. The discovery document and signing keys
+// are fetched on first use and cached.
+type OIDCProvider struct {
+	cfg    config.OIDCProviderConfig
+	client *http.Client
+
+	mu            sync.Mutex
+	metadata      *oidcMetadata
+	keys          map[string]interface{}
+	keysFetchedAt time.Time
+}
+
+type oidcMetadata struct {
+	Issuer        string `json:"issuer"`
+	TokenEndpoint string `json:"token_endpoint"`
+	JWKSURI       string `json:"jwks_uri"`
+}
+
+// oidcIDTokenClaims are the ID token claims checked or used beyond the
+// registered ones.
+type oidcIDTokenClaims struct {
+	Nonce             string   `json:"nonce"`
+	AuthorizedParty   string   `json:"azp"`
+	Email             string   `json:"email"`
+	EmailVerified     flexBool `json:"email_verified"`
+	Name              string   `json:"name"`
+	PreferredUsername string   `json:"preferred_username"`
+	jwt.RegisteredClaims
+}
+
+// flexBool accepts booleans sent as JSON strings, which some providers do
+// for email_verified.
+type flexBool bool
+
+func (b *flexBool) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case bool:
+		*b = flexBool(v)
+	case string:
+		*b = flexBool(v == "true")
+	}
+	return nil
+}
+
+func NewOIDCProvider(cfg config.OIDCProviderConfig, timeout time.Duration) (*OIDCProvider, error) {
+	if cfg.Issuer == "" || cfg.ClientID == "" || cfg.RedirectURI == "" {
+		return nil, errors.New("issuer, client ID and redirect URI are required")
+	}
+	return &OIDCProvider{cfg: cfg, client: &http.Client{Timeout: timeout}}, nil
+}
+
+// TrustsEmail reports whether email addresses the provider says are
+// verified count as verified here, and may link to existing accounts.
+func (p *OIDCProvider) TrustsEmail() bool {
+	return p.cfg.TrustEmail
+}
+
+// Authenticate exchanges an authorization code for an ID token and returns
+// the identity it asserts. codeVerifier is the PKCE verifier, if the
+// authorization request had a challenge. nonce, when not empty, must match
+// the nonce of the ID token.
+func (p *OIDCProvider) Authenticate(ctx context.Context, code, codeVerifier, nonce string) (*OIDCIdentity, error) {
+	metadata, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rawIDToken, err := p.exchange(ctx, metadata, code, codeVerifier)
+	if err != nil {
+		return nil, err
+	}
+	return p.verify(ctx, metadata, rawIDToken, nonce)
+}
+
+func (p *OIDCProvider) exchange(ctx context.Context, metadata *oidcMetadata, code, codeVerifier string) (string, error) {
+	form := url.Values{
+		"grant_type":   {"authorization_code"},
+		"code":         {code},
+		"redirect_uri": {p.cfg.RedirectURI},
+	}
+	if codeVerifier != "" {
+		form.Set("code_verifier", codeVerifier)
+	}
+	if p.cfg.ClientSecret == "" {
+		form.Set("client_id", p.cfg.ClientID)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		// RFC 6749 section 2.3.1 form-encodes both before basic auth
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	var body struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&body); err != nil {
+		return "", fmt.Errorf("token request failed with %s: %w", resp.Status, err)
+	}
+	if resp.StatusCode == http.StatusBadRequest && body.Error != "" {
+		return "", fmt.Errorf("%w: %s %s", ErrOIDCRejected, body.Error, body.ErrorDescription)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request failed with %s", resp.Status)
+	}
+	if body.IDToken == "" {
+		return "", fmt.Errorf("%w: no ID token in the token response", ErrOIDCRejected)
+	}
+	return body.IDToken, nil
+}
+
+func (p *OIDCProvider) verify(ctx context.Context, metadata *oidcMetadata, rawIDToken, nonce string) (*OIDCIdentity, error) {
+	claims := &oidcIDTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.key(ctx, metadata, kid)
+	},
+		jwt.WithValidMethods(oidcSigningMethods),
+		jwt.WithIssuer(metadata.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(oidcClockSkew),
+	)
+	if err != nil {
+		if errors.Is(err, errOIDCKeysUnavailable) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %v", ErrOIDCRejected, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: ID token has no subject", ErrOIDCRejected)
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.cfg.ClientID {
+		return nil, fmt.Errorf("%w: ID token was issued to %q", ErrOIDCRejected, claims.AuthorizedParty)
+	}
+	if nonce != "" && claims.Nonce != nonce {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrOIDCRejected)
+	}
+	return &OIDCIdentity{
+		Subject:           claims.Subject,
+		Email:             claims.Email,
+		EmailVerified:     bool(claims.EmailVerified),
+		Name:              claims.Name,
+		PreferredUsername: claims.PreferredUsername,
+	}, nil
+}
+
+// discover fetches the provider's discovery document once. A failed fetch
+// is retried on the next login.
+func (p *OIDCProvider) discover(ctx context.Context) (*oidcMetadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	var metadata oidcMetadata
+	if err := p.getJSON(ctx, p.cfg.Issuer+"/.well-known/openid-configuration", &metadata); err != nil {
+		return nil, fmt.Errorf("discovery failed: %w", err)
+	}
+	// OpenID Connect Discovery section 4.3
+	if metadata.Issuer != p.cfg.Issuer {
+		return nil, fmt.Errorf("discovery returned issuer %q, expected %q", metadata.Issuer, p.cfg.Issuer)
+	}
+	if metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		return nil, errors.New("discovery document lacks token_endpoint or jwks_uri")
+	}
+	p.metadata = &metadata
+	return p.metadata, nil
+}
+
+// key returns the signing key named kid, fetching the JWKS again when the
+// provider may have rotated its keys. Tokens without a kid are accepted
+// when the provider has a single key.
+func (p *OIDCProvider) key(ctx context.Context, metadata *oidcMetadata, kid string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	if time.Since(p.keysFetchedAt) < oidcKeyRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	var jwks JWKS
+	if err := p.getJSON(ctx, metadata.JWKSURI, &jwks); err != nil {
+		return nil, fmt.Errorf("%w: %v", errOIDCKeysUnavailable, err)
+	}
+	keys := make(map[string]interface{}, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := jwk.PublicKey()
+		if err != nil {
+			// Keys of types we cannot use don't spoil the others
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	p.keys, p.keysFetchedAt = keys, time.Now()
+
+	if key, ok := p.lookupKey(kid); ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (p *OIDCProvider) lookupKey(kid string) (interface{}, bool) {
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, true
+		}
+	}
+	key, ok := p.keys[kid]
+	return key, ok
+}
+
+func (p *OIDCProvider) getJSON(ctx context.Context, target string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", target, resp.Status)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(v)
+}
@@ -0,0 +1,39 @@
+package infrastructure
+
+import (
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"gorm.io/gorm"
+)
+
+type OneTimeTokenRepository struct {
+	DB *gorm.DB
+}
+
+func NewOneTimeTokenRepository(db *gorm.DB) *OneTimeTokenRepository {
+	return &OneTimeTokenRepository{DB: db}
+}
+
+func (r *OneTimeTokenRepository) Create(token *entity.OneTimeToken) error {
+	return r.DB.Create(token).Error
+}
+
+func (r *OneTimeTokenRepository) FindByTokenHash(purpose, tokenHash string) (*entity.OneTimeToken, error) {
+	var token entity.OneTimeToken
+	err := r.DB.Where("purpose = ? AND token_hash = ?", purpose, tokenHash).First(&token).Error
+	return &token, err
+}
+
+func (r *OneTimeTokenRepository) MarkUsed(id uint) (bool, error) {
+	result := r.DB.Model(&entity.OneTimeToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *OneTimeTokenRepository) InvalidateForUser(userID uint, purpose string) error {
+	return r.DB.Model(&entity.OneTimeToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+}
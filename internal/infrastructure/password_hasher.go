@@ -0,0 +1,86 @@
+package infrastructure
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/aungmyozaw92/go-grpc-starter/config"
+)
+
+var (
+	// ErrUnsupportedHash is returned for a stored hash no hasher recognises.
+	ErrUnsupportedHash = errors.New("unsupported password hash format")
+	// ErrPasswordTooLong is returned when the algorithm cannot take the
+	// whole password; bcrypt would otherwise silently ignore the excess.
+	ErrPasswordTooLong = errors.New("password too long for the hashing algorithm")
+)
+
+// PasswordHasher hashes passwords into self-describing strings that record
+// the algorithm and its parameters, so stored hashes stay verifiable after
+// the configuration changes.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Verify reports whether password matches encodedHash.
+	Verify(encodedHash, password string) (bool, error)
+	// NeedsRehash reports whether encodedHash was made with another
+	// algorithm or weaker parameters than the current configuration.
+	NeedsRehash(encodedHash string) bool
+}
+
+// formatHasher is a PasswordHasher for one hash format.
+type formatHasher interface {
+	PasswordHasher
+	Recognizes(encodedHash string) bool
+}
+
+// NewPasswordHasher returns a hasher that creates hashes with the configured
+// algorithm and verifies hashes made by any supported algorithm.
+func NewPasswordHasher(cfg config.PasswordHashConfig) (PasswordHasher, error) {
+	argon2id, err := NewArgon2idHasher(Argon2idParams{
+		Memory:      cfg.Argon2Memory,
+		Iterations:  cfg.Argon2Iterations,
+		Parallelism: cfg.Argon2Parallelism,
+		SaltLength:  cfg.Argon2SaltLength,
+		KeyLength:   cfg.Argon2KeyLength,
+	})
+	if err != nil {
+		return nil, err
+	}
+	bcrypt, err := NewBcryptHasher(cfg.BcryptCost)
+	if err != nil {
+		return nil, err
+	}
+
+	var current formatHasher
+	switch cfg.Algorithm {
+	case "argon2id":
+		current = argon2id
+	case "bcrypt":
+		current = bcrypt
+	default:
+		return nil, fmt.Errorf("unknown password hash algorithm %q", cfg.Algorithm)
+	}
+	return &multiHasher{current: current, all: []formatHasher{argon2id, bcrypt}}, nil
+}
+
+type multiHasher struct {
+	current formatHasher
+	all     []formatHasher
+}
+
+func (h *multiHasher) Hash(password string) (string, error) {
+	return h.current.Hash(password)
+}
+
+func (h *multiHasher) Verify(encodedHash, password string) (bool, error) {
+	for _, hasher := range h.all {
+		if hasher.Recognizes(encodedHash) {
+			return hasher.Verify(encodedHash, password)
+		}
+	}
+	return false, ErrUnsupportedHash
+}
+
+func (h *multiHasher) NeedsRehash(encodedHash string) bool {
+	return !h.current.Recognizes(encodedHash) || h.current.NeedsRehash(encodedHash)
+}
@@ -0,0 +1,43 @@
+package infrastructure
+
+import (
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"gorm.io/gorm"
+)
+
+type PasswordHistoryRepository struct {
+	DB *gorm.DB
+}
+
+func NewPasswordHistoryRepository(db *gorm.DB) *PasswordHistoryRepository {
+	return &PasswordHistoryRepository{DB: db}
+}
+
+func (r *PasswordHistoryRepository) Recent(userID uint, limit int) ([]entity.PasswordHistory, error) {
+	var entries []entity.PasswordHistory
+	err := r.DB.Where("user_id = ?", userID).Order("id DESC").Limit(limit).Find(&entries).Error
+	return entries, err
+}
+
+func (r *PasswordHistoryRepository) Add(entry *entity.PasswordHistory, keep int) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+
+		// MySQL does not allow LIMIT in an IN subquery, so look the ids up first
+		var stale []uint
+		if err := tx.Model(&entity.PasswordHistory{}).
+			Where("user_id = ?", entry.UserID).
+			Order("id DESC").
+			Offset(keep).
+			Limit(1000).
+			Pluck("id", &stale).Error; err != nil {
+			return err
+		}
+		if len(stale) == 0 {
+			return nil
+		}
+		return tx.Delete(&entity.PasswordHistory{}, stale).Error
+	})
+}
@@ -0,0 +1,45 @@
+package infrastructure
+
+import (
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository struct {
+	DB *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{DB: db}
+}
+
+func (r *RefreshTokenRepository) Create(token *entity.RefreshToken) error {
+	return r.DB.Create(token).Error
+}
+
+func (r *RefreshTokenRepository) FindByTokenHash(tokenHash string) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	err := r.DB.Where("token_hash = ?", tokenHash).First(&token).Error
+	return &token, err
+}
+
+func (r *RefreshTokenRepository) MarkUsed(id uint) (bool, error) {
+	result := r.DB.Model(&entity.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(familyID string) error {
+	return r.DB.Model(&entity.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *RefreshTokenRepository) RevokeAllForUser(userID uint) error {
+	return r.DB.Model(&entity.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
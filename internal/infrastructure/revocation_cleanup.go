@@ -0,0 +1,38 @@
+package infrastructure
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/repository"
+)
+
+// StartRevocationCleanup periodically purges expired revocation entries until
+// ctx is cancelled.
+func StartRevocationCleanup(ctx context.Context, repo repository.TokenRevocationRepository, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("token revocation cleanup interval must be positive, got %s", interval)
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case now := <-ticker.C:
+				deleted, err := repo.DeleteExpired(now)
+				if err != nil {
+					log.Printf("Failed to clean up revoked tokens: %v", err)
+					continue
+				}
+				if deleted > 0 {
+					log.Printf("Cleaned up %d expired token revocations", deleted)
+				}
+			}
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,87 @@
+package infrastructure
+
+import (
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RoleRepository struct {
+	DB *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) *RoleRepository {
+	return &RoleRepository{DB: db}
+}
+
+func (r *RoleRepository) Create(role *entity.Role) error {
+	return r.DB.Create(role).Error
+}
+
+func (r *RoleRepository) Update(role *entity.Role) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Permissions").Save(role).Error; err != nil {
+			return err
+		}
+		return tx.Model(role).Association("Permissions").Replace(role.Permissions)
+	})
+}
+
+func (r *RoleRepository) Delete(id uint) error {
+	return r.DB.Transaction(func(tx *gorm.DB) error {
+		role := &entity.Role{ID: id}
+		if err := tx.Model(role).Association("Permissions").Clear(); err != nil {
+			return err
+		}
+		return tx.Delete(role).Error
+	})
+}
+
+func (r *RoleRepository) FindByID(id uint) (*entity.Role, error) {
+	var role entity.Role
+	err := r.DB.Preload("Permissions").First(&role, id).Error
+	return &role, err
+}
+
+func (r *RoleRepository) FindByName(name string) (*entity.Role, error) {
+	var role entity.Role
+	err := r.DB.Preload("Permissions").Where("name = ?", name).First(&role).Error
+	return &role, err
+}
+
+func (r *RoleRepository) List() ([]*entity.Role, error) {
+	var roles []*entity.Role
+	err := r.DB.Preload("Permissions").Order("id ASC").Find(&roles).Error
+	return roles, err
+}
+
+func (r *RoleRepository) ExistsByName(name string) (bool, error) {
+	var count int64
+	err := r.DB.Model(&entity.Role{}).Where("name = ?", name).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *RoleRepository) ExistsByNameExcludeID(name string, excludeID uint) (bool, error) {
+	var count int64
+	err := r.DB.Model(&entity.Role{}).Where("name = ? AND id != ?", name, excludeID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *RoleRepository) ListPermissions() ([]*entity.Permission, error) {
+	var permissions []*entity.Permission
+	err := r.DB.Order("name ASC").Find(&permissions).Error
+	return permissions, err
+}
+
+func (r *RoleRepository) FindPermissionsByNames(names []string) ([]entity.Permission, error) {
+	var permissions []entity.Permission
+	if len(names) == 0 {
+		return permissions, nil
+	}
+	err := r.DB.Where("name IN ?", names).Find(&permissions).Error
+	return permissions, err
+}
+
+func (r *RoleRepository) EnsurePermissions(permissions []entity.Permission) error {
+	return r.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&permissions).Error
+}
@@ -0,0 +1,49 @@
+package infrastructure
+
+import (
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"gorm.io/gorm"
+)
+
+type SessionRepository struct {
+	DB *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) *SessionRepository {
+	return &SessionRepository{DB: db}
+}
+
+func (r *SessionRepository) Create(session *entity.Session) error {
+	return r.DB.Create(session).Error
+}
+
+func (r *SessionRepository) FindActiveByID(id uint) (*entity.Session, error) {
+	var session entity.Session
+	err := r.active().Where("sessions.id = ?", id).First(&session).Error
+	return &session, err
+}
+
+func (r *SessionRepository) FindActiveByFamilyID(familyID string) (*entity.Session, error) {
+	var session entity.Session
+	err := r.active().Where("sessions.family_id = ?", familyID).First(&session).Error
+	return &session, err
+}
+
+func (r *SessionRepository) ListActive(userID uint) ([]*entity.Session, error) {
+	var sessions []*entity.Session
+	err := r.active().Where("sessions.user_id = ?", userID).Order("sessions.last_used_at DESC").Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *SessionRepository) MarkUsed(familyID string, usedAt time.Time) error {
+	return r.DB.Model(&entity.Session{}).Where("family_id = ?", familyID).Update("last_used_at", usedAt).Error
+}
+
+// active limits a query to sessions whose refresh token family is still live.
+func (r *SessionRepository) active() *gorm.DB {
+	live := r.DB.Model(&entity.RefreshToken{}).Select("1").
+		Where("refresh_tokens.family_id = sessions.family_id AND refresh_tokens.revoked_at IS NULL AND refresh_tokens.expires_at > ?", time.Now())
+	return r.DB.Model(&entity.Session{}).Where("EXISTS (?)", live)
+}
@@ -0,0 +1,77 @@
+package infrastructure
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryTokenRevocationRepository keeps revocations in process memory. It is
+// suitable for single-instance deployments and tests; revocations are lost on
+// restart and are not shared between replicas.
+type MemoryTokenRevocationRepository struct {
+	mu     sync.RWMutex
+	tokens map[string]time.Time
+	users  map[uint]userRevocation
+}
+
+type userRevocation struct {
+	issuedBefore time.Time
+	expiresAt    time.Time
+}
+
+func NewMemoryTokenRevocationRepository() *MemoryTokenRevocationRepository {
+	return &MemoryTokenRevocationRepository{
+		tokens: make(map[string]time.Time),
+		users:  make(map[uint]userRevocation),
+	}
+}
+
+func (r *MemoryTokenRevocationRepository) RevokeToken(jti string, userID uint, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tokens[jti] = expiresAt
+	return nil
+}
+
+func (r *MemoryTokenRevocationRepository) RevokeAllForUser(userID uint, issuedBefore, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// Only the most recent cutoff matters for a user
+	if existing, ok := r.users[userID]; ok && existing.issuedBefore.After(issuedBefore) {
+		return nil
+	}
+	r.users[userID] = userRevocation{issuedBefore: issuedBefore, expiresAt: expiresAt}
+	return nil
+}
+
+func (r *MemoryTokenRevocationRepository) IsRevoked(jti string, userID uint, issuedAt time.Time) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	now := time.Now()
+	if expiresAt, ok := r.tokens[jti]; ok && jti != "" && expiresAt.After(now) {
+		return true, nil
+	}
+	if revocation, ok := r.users[userID]; ok && revocation.expiresAt.After(now) {
+		return !issuedAt.After(revocation.issuedBefore), nil
+	}
+	return false, nil
+}
+
+func (r *MemoryTokenRevocationRepository) DeleteExpired(now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var deleted int64
+	for jti, expiresAt := range r.tokens {
+		if !expiresAt.After(now) {
+			delete(r.tokens, jti)
+			deleted++
+		}
+	}
+	for userID, revocation := range r.users {
+		if !revocation.expiresAt.After(now) {
+			delete(r.users, userID)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
@@ -0,0 +1,47 @@
+package infrastructure
+
+import (
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"gorm.io/gorm"
+)
+
+type TokenRevocationRepository struct {
+	DB *gorm.DB
+}
+
+func NewTokenRevocationRepository(db *gorm.DB) *TokenRevocationRepository {
+	return &TokenRevocationRepository{DB: db}
+}
+
+func (r *TokenRevocationRepository) RevokeToken(jti string, userID uint, expiresAt time.Time) error {
+	return r.DB.Create(&entity.RevokedToken{
+		JTI:       jti,
+		UserID:    userID,
+		ExpiresAt: expiresAt,
+	}).Error
+}
+
+func (r *TokenRevocationRepository) RevokeAllForUser(userID uint, issuedBefore, expiresAt time.Time) error {
+	return r.DB.Create(&entity.RevokedToken{
+		UserID:       userID,
+		IssuedBefore: &issuedBefore,
+		ExpiresAt:    expiresAt,
+	}).Error
+}
+
+func (r *TokenRevocationRepository) IsRevoked(jti string, userID uint, issuedAt time.Time) (bool, error) {
+	var count int64
+	err := r.DB.Model(&entity.RevokedToken{}).
+		Where("expires_at > ?", time.Now()).
+		Where(r.DB.Where("jti = ? AND jti != ''", jti).
+			Or("user_id = ? AND issued_before >= ?", userID, issuedAt)).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *TokenRevocationRepository) DeleteExpired(now time.Time) (int64, error) {
+	result := r.DB.Where("expires_at <= ?", now).Delete(&entity.RevokedToken{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,133 @@
+package infrastructure
+
+import (
+	"log"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"gorm.io/gorm"
+)
+
+// identityCollision is a group of accounts whose usernames or email
+// addresses only differ in case or Unicode form. The first user keeps the
+// normalized value; the others sign in with their exact value until they
+// are renamed.
+type identityCollision struct {
+	// Field is "username" or "email".
+	Field      string
+	Normalized string
+	UserIDs    []uint
+	Values     []string
+}
+
+// backfillUserIdentity fills in the normalized username and email of users
+// created before they were stored, and logs the accounts that collide. It is
+// the step of migration 0003.
+func backfillUserIdentity(db *gorm.DB) error {
+	collisions, err := normalizeUserIdentities(db)
+	if err != nil {
+		return err
+	}
+	for _, c := range collisions {
+		log.Printf("Warning: users %v share the %s %q in different forms %q; rename all but user %d",
+			c.UserIDs, c.Field, c.Normalized, c.Values, c.UserIDs[0])
+	}
+	return nil
+}
+
+func normalizeUserIdentities(db *gorm.DB) ([]identityCollision, error) {
+	var users []*entity.User
+	// Deleted accounts keep their names reserved, so they take part too
+	if err := db.Unscoped().
+		Select("id", "username", "email", "username_normalized", "email_normalized").
+		Order("id").Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	var collisions []identityCollision
+	for _, field := range []identityField{usernameField, emailField} {
+		found, err := migrateIdentityField(db, users, field)
+		if err != nil {
+			return nil, err
+		}
+		collisions = append(collisions, found...)
+	}
+	return collisions, nil
+}
+
+type identityField struct {
+	name      string
+	value     func(*entity.User) string
+	stored    func(*entity.User) *string
+	normalize func(string) string
+}
+
+var (
+	usernameField = identityField{
+		name:      "username",
+		value:     func(u *entity.User) string { return u.Username },
+		stored:    func(u *entity.User) *string { return u.UsernameNormalized },
+		normalize: entity.NormalizeUsername,
+	}
+	emailField = identityField{
+		name: "email",
+		value: func(u *entity.User) string {
+			if u.Email == nil {
+				return ""
+			}
+			return *u.Email
+		},
+		stored:    func(u *entity.User) *string { return u.EmailNormalized },
+		normalize: entity.NormalizeEmail,
+	}
+)
+
+func migrateIdentityField(db *gorm.DB, users []*entity.User, field identityField) ([]identityCollision, error) {
+	var keys []string
+	groups := make(map[string][]*entity.User)
+	for _, user := range users {
+		value := field.value(user)
+		if value == "" {
+			continue
+		}
+		key := field.normalize(value)
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], user)
+	}
+
+	var collisions []identityCollision
+	for _, key := range keys {
+		group := groups[key]
+
+		// The account that already holds the value keeps it, else the oldest
+		owner := group[0]
+		for _, user := range group {
+			if stored := field.stored(user); stored != nil && *stored == key {
+				owner = user
+				break
+			}
+		}
+		if field.stored(owner) == nil {
+			if err := db.Unscoped().Model(&entity.User{}).Where("id = ?", owner.ID).
+				UpdateColumn(field.name+"_normalized", key).Error; err != nil {
+				return nil, err
+			}
+		}
+
+		if len(group) == 1 {
+			continue
+		}
+		collision := identityCollision{Field: field.name, Normalized: key}
+		collision.UserIDs = append(collision.UserIDs, owner.ID)
+		collision.Values = append(collision.Values, field.value(owner))
+		for _, user := range group {
+			if user != owner {
+				collision.UserIDs = append(collision.UserIDs, user.ID)
+				collision.Values = append(collision.Values, field.value(user))
+			}
+		}
+		collisions = append(collisions, collision)
+	}
+	return collisions, nil
+}
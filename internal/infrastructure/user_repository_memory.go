@@ -0,0 +1,380 @@
+package infrastructure
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"gorm.io/gorm"
+)
+
+// MemoryUserRepository keeps users in process memory, for tests and demo
+// mode. It behaves like UserRepository: the unique indexes cover deleted
+// users too, while lookups, counts and the Exists methods skip them. Values
+// are compared as they are, where MySQL would apply its collation; the
+// normalized forms already treat most such variants as equal.
+type MemoryUserRepository struct {
+	mu     sync.RWMutex
+	users  map[uint]*entity.User
+	lastID uint
+}
+
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{users: make(map[uint]*entity.User)}
+}
+
+// userIndexes are the unique indexes of the users table, in the order
+// violations are reported.
+var userIndexes = []struct {
+	name string
+	key  func(user *entity.User) *string
+}{
+	{"idx_users_username", func(user *entity.User) *string { return &user.Username }},
+	{"idx_users_email", func(user *entity.User) *string { return user.Email }},
+	{"idx_users_username_normalized", func(user *entity.User) *string { return user.UsernameNormalized }},
+	{"idx_users_email_normalized", func(user *entity.User) *string { return user.EmailNormalized }},
+}
+
+func (r *MemoryUserRepository) Create(ctx context.Context, user *entity.User) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	user.NormalizeIdentity()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.insert(user)
+}
+
+// insert stores a copy of user, filling in what the database would: the ID,
+// the timestamps and the column defaults.
+func (r *MemoryUserRepository) insert(user *entity.User) error {
+	if user.ID != 0 {
+		if _, ok := r.users[user.ID]; ok {
+			return duplicateKey("PRIMARY")
+		}
+	}
+	if err := r.checkUnique(user); err != nil {
+		return err
+	}
+
+	if user.ID == 0 {
+		user.ID = r.lastID + 1
+	}
+	if user.ID > r.lastID {
+		r.lastID = user.ID
+	}
+	now := time.Now()
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = now
+	}
+	if user.UpdatedAt.IsZero() {
+		user.UpdatedAt = now
+	}
+	if user.Status == "" {
+		user.Status = entity.UserStatusActive
+	}
+	if user.RoleID == 0 {
+		user.RoleID = 1
+	}
+	r.users[user.ID] = cloneUser(user)
+	return nil
+}
+
+// checkUnique reports the first unique index user would violate against
+// the other stored users.
+func (r *MemoryUserRepository) checkUnique(user *entity.User) error {
+	for _, index := range userIndexes {
+		key := index.key(user)
+		if key == nil {
+			continue
+		}
+		for id, other := range r.users {
+			if otherKey := index.key(other); id != user.ID && otherKey != nil && *otherKey == *key {
+				return userIndexError(index.name)
+			}
+		}
+	}
+	return nil
+}
+
+func (r *MemoryUserRepository) FindByUsername(ctx context.Context, username string) (*entity.User, error) {
+	return r.findByIdentity(ctx, username, entity.NormalizeUsername(username), func(user *entity.User) (*string, *string) {
+		return &user.Username, user.UsernameNormalized
+	})
+}
+
+func (r *MemoryUserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	return r.findByIdentity(ctx, email, entity.NormalizeEmail(email), func(user *entity.User) (*string, *string) {
+		return user.Email, user.EmailNormalized
+	})
+}
+
+// findByIdentity prefers an exact match among users without a normalized
+// form, as UserRepository.findByIdentity does.
+func (r *MemoryUserRepository) findByIdentity(ctx context.Context, value, normalized string, fields func(user *entity.User) (*string, *string)) (*entity.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	legacy := r.first(func(user *entity.User) bool {
+		raw, norm := fields(user)
+		return norm == nil && raw != nil && *raw == value
+	})
+	if legacy != nil {
+		return legacy, nil
+	}
+	user := r.first(func(user *entity.User) bool {
+		_, norm := fields(user)
+		return norm != nil && *norm == normalized
+	})
+	if user == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return user, nil
+}
+
+// first returns a copy of the live user with the lowest ID that matches.
+func (r *MemoryUserRepository) first(match func(user *entity.User) bool) *entity.User {
+	var found *entity.User
+	for _, user := range r.users {
+		if !user.DeletedAt.Valid && match(user) && (found == nil || user.ID < found.ID) {
+			found = user
+		}
+	}
+	if found == nil {
+		return nil
+	}
+	return cloneUser(found)
+}
+
+func (r *MemoryUserRepository) FindByID(ctx context.Context, id int) (*entity.User, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[uint(id)]
+	if !ok || user.DeletedAt.Valid {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return cloneUser(user), nil
+}
+
+// Update saves every field of user, like gorm's Save: a user without an ID
+// is created, and any other replaces the stored one or is inserted.
+func (r *MemoryUserRepository) Update(ctx context.Context, user *entity.User) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users[user.ID]; !ok {
+		return r.insert(user)
+	}
+	if err := r.checkUnique(user); err != nil {
+		return err
+	}
+	user.UpdatedAt = time.Now()
+	r.users[user.ID] = cloneUser(user)
+	return nil
+}
+
+func (r *MemoryUserRepository) UpdatePassword(ctx context.Context, id uint, passwordHash string) error {
+	return r.update(ctx, id, func(user *entity.User) {
+		user.Password = passwordHash
+	})
+}
+
+func (r *MemoryUserRepository) SetPassword(ctx context.Context, id uint, passwordHash string, changedAt time.Time, changeRequired bool) error {
+	return r.update(ctx, id, func(user *entity.User) {
+		user.Password = passwordHash
+		user.PasswordChangedAt = &changedAt
+		user.PasswordChangeRequired = changeRequired
+	})
+}
+
+func (r *MemoryUserRepository) UpdateStatus(ctx context.Context, id uint, status entity.UserStatus, reason string, expiresAt *time.Time) error {
+	return r.update(ctx, id, func(user *entity.User) {
+		user.Status = status
+		user.StatusReason = reason
+		user.StatusExpiresAt = clonePtr(expiresAt)
+	})
+}
+
+func (r *MemoryUserRepository) MarkEmailVerified(ctx context.Context, id uint, email string, verifiedAt time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt.Valid || user.Email == nil || *user.Email != email {
+		return nil
+	}
+	if user.EmailVerifiedAt == nil {
+		user.EmailVerifiedAt = &verifiedAt
+	}
+	if user.Status == entity.UserStatusPending {
+		user.Status = entity.UserStatusActive
+	}
+	user.UpdatedAt = time.Now()
+	return nil
+}
+
+func (r *MemoryUserRepository) Delete(ctx context.Context, id int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if user, ok := r.users[uint(id)]; ok && !user.DeletedAt.Valid {
+		user.Status = entity.UserStatusDeleted
+		user.UpdatedAt = time.Now()
+		user.DeletedAt = gorm.DeletedAt{Time: user.UpdatedAt, Valid: true}
+	}
+	return nil
+}
+
+// update applies change to the live user id, if there is one, and bumps
+// UpdatedAt like gorm's Updates.
+func (r *MemoryUserRepository) update(ctx context.Context, id uint, change func(user *entity.User)) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if user, ok := r.users[id]; ok && !user.DeletedAt.Valid {
+		change(user)
+		user.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (r *MemoryUserRepository) GetUserList(ctx context.Context, page, limit int, search string) ([]*entity.User, int64, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, 0, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	search = strings.ToLower(search)
+	contains := func(s string) bool {
+		return strings.Contains(strings.ToLower(s), search)
+	}
+	var users []*entity.User
+	for _, user := range r.users {
+		if user.DeletedAt.Valid {
+			continue
+		}
+		if search != "" && !contains(user.Username) && !contains(user.Name) && (user.Email == nil || !contains(*user.Email)) {
+			continue
+		}
+		users = append(users, user)
+	}
+	total := int64(len(users))
+
+	// Newest first; the ID breaks ties the database leaves unordered
+	sort.Slice(users, func(i, j int) bool {
+		if !users[i].CreatedAt.Equal(users[j].CreatedAt) {
+			return users[i].CreatedAt.After(users[j].CreatedAt)
+		}
+		return users[i].ID > users[j].ID
+	})
+
+	// As with LIMIT and OFFSET, a negative limit means none
+	offset := (page - 1) * limit
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(users) {
+		offset = len(users)
+	}
+	users = users[offset:]
+	if limit >= 0 && limit < len(users) {
+		users = users[:limit]
+	}
+
+	result := make([]*entity.User, len(users))
+	for i, user := range users {
+		result[i] = cloneUser(user)
+	}
+	return result, total, nil
+}
+
+func (r *MemoryUserRepository) CountByRole(ctx context.Context, roleID uint) (int64, error) {
+	return r.count(ctx, func(user *entity.User) bool {
+		return user.RoleID == int(roleID)
+	})
+}
+
+func (r *MemoryUserRepository) ExistsByUsername(ctx context.Context, username string) (bool, error) {
+	return r.ExistsByUsernameExcludeID(ctx, username, 0)
+}
+
+func (r *MemoryUserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
+	return r.ExistsByEmailExcludeID(ctx, email, 0)
+}
+
+func (r *MemoryUserRepository) ExistsByUsernameExcludeID(ctx context.Context, username string, excludeID uint) (bool, error) {
+	normalized := entity.NormalizeUsername(username)
+	count, err := r.count(ctx, func(user *entity.User) bool {
+		return user.ID != excludeID && user.UsernameNormalized != nil && *user.UsernameNormalized == normalized
+	})
+	return count > 0, err
+}
+
+func (r *MemoryUserRepository) ExistsByEmailExcludeID(ctx context.Context, email string, excludeID uint) (bool, error) {
+	normalized := entity.NormalizeEmail(email)
+	count, err := r.count(ctx, func(user *entity.User) bool {
+		return user.ID != excludeID && user.EmailNormalized != nil && *user.EmailNormalized == normalized
+	})
+	return count > 0, err
+}
+
+func (r *MemoryUserRepository) count(ctx context.Context, match func(user *entity.User) bool) (int64, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var count int64
+	for _, user := range r.users {
+		if !user.DeletedAt.Valid && match(user) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// cloneUser copies user so that callers cannot change stored users through
+// the pointers it shares.
+func cloneUser(user *entity.User) *entity.User {
+	clone := *user
+	clone.Email = clonePtr(user.Email)
+	clone.UsernameNormalized = clonePtr(user.UsernameNormalized)
+	clone.EmailNormalized = clonePtr(user.EmailNormalized)
+	clone.EmailVerifiedAt = clonePtr(user.EmailVerifiedAt)
+	clone.PasswordChangedAt = clonePtr(user.PasswordChangedAt)
+	clone.StatusExpiresAt = clonePtr(user.StatusExpiresAt)
+	return &clone
+}
+
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
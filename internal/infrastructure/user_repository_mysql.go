@@ -1,7 +1,13 @@
 package infrastructure
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/repository"
 	"gorm.io/gorm"
 )
 
@@ -13,46 +19,114 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 	return &UserRepository{DB: db}
 }
 
-func (r *UserRepository) Create(user *entity.User) error {
-	return r.DB.Create(user).Error
+func (r *UserRepository) Create(ctx context.Context, user *entity.User) error {
+	user.NormalizeIdentity()
+	return duplicateUserError(r.DB.WithContext(ctx).Create(user).Error)
 }
 
-func (r *UserRepository) FindByUsername(username string) (*entity.User, error) {
-	var user entity.User
-	err := r.DB.Where("username = ?", username).First(&user).Error
-	return &user, err
+// duplicateUserError translates unique violations, see userIndexError, and
+// returns other errors as they are.
+func duplicateUserError(err error) error {
+	if key, ok := uniqueViolation(err); ok {
+		return userIndexError(key)
+	}
+	return err
+}
+
+// userIndexError returns the violation of the unique index key of the users
+// table as repository.ErrDuplicateUsername or ErrDuplicateEmail. SQLite
+// names the column instead of the index.
+func userIndexError(key string) error {
+	switch key {
+	case "idx_users_username", "idx_users_username_normalized", "users.username", "users.username_normalized":
+		return fmt.Errorf("%w: %s", repository.ErrDuplicateUsername, key)
+	case "idx_users_email", "idx_users_email_normalized", "users.email", "users.email_normalized":
+		return fmt.Errorf("%w: %s", repository.ErrDuplicateEmail, key)
+	}
+	return duplicateKey(key)
+}
+
+func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*entity.User, error) {
+	return r.findByIdentity(ctx, "username", username, entity.NormalizeUsername(username))
 }
 
-func (r *UserRepository) FindByEmail(email string) (*entity.User, error) {
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
+	return r.findByIdentity(ctx, "email", email, entity.NormalizeEmail(email))
+}
+
+// findByIdentity looks a user up by the normalized form of column. Accounts
+// left without one by a collision, see backfillUserIdentity, are still found
+// by their exact value.
+func (r *UserRepository) findByIdentity(ctx context.Context, column, value, normalized string) (*entity.User, error) {
+	var legacy entity.User
+	err := r.DB.WithContext(ctx).Where(column+"_normalized IS NULL AND "+column+" = ?", value).First(&legacy).Error
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return &legacy, err
+	}
+
 	var user entity.User
-	err := r.DB.Where("email = ?", email).First(&user).Error
+	err = r.DB.WithContext(ctx).Where(column+"_normalized = ?", normalized).First(&user).Error
 	return &user, err
 }
 
-func (r *UserRepository) FindByID(id int) (*entity.User, error) {
+func (r *UserRepository) FindByID(ctx context.Context, id int) (*entity.User, error) {
 	var user entity.User
-	err := r.DB.First(&user, id).Error
+	err := r.DB.WithContext(ctx).First(&user, id).Error
 	return &user, err
 }
 
-func (r *UserRepository) Update(user *entity.User) error {
-	return r.DB.Save(user).Error
+func (r *UserRepository) Update(ctx context.Context, user *entity.User) error {
+	return duplicateUserError(r.DB.WithContext(ctx).Save(user).Error)
+}
+
+func (r *UserRepository) UpdatePassword(ctx context.Context, id uint, passwordHash string) error {
+	return r.DB.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).Update("password", passwordHash).Error
+}
+
+func (r *UserRepository) SetPassword(ctx context.Context, id uint, passwordHash string, changedAt time.Time, changeRequired bool) error {
+	return r.DB.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"password":                 passwordHash,
+		"password_changed_at":      changedAt,
+		"password_change_required": changeRequired,
+	}).Error
+}
+
+func (r *UserRepository) UpdateStatus(ctx context.Context, id uint, status entity.UserStatus, reason string, expiresAt *time.Time) error {
+	return r.DB.WithContext(ctx).Model(&entity.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"status":            status,
+		"status_reason":     reason,
+		"status_expires_at": expiresAt,
+	}).Error
+}
+
+func (r *UserRepository) MarkEmailVerified(ctx context.Context, id uint, email string, verifiedAt time.Time) error {
+	return r.DB.WithContext(ctx).Model(&entity.User{}).Where("id = ? AND email = ?", id, email).Updates(map[string]interface{}{
+		"email_verified_at": gorm.Expr("COALESCE(email_verified_at, ?)", verifiedAt),
+		"status":            gorm.Expr("CASE WHEN status = ? THEN ? ELSE status END", entity.UserStatusPending, entity.UserStatusActive),
+	}).Error
 }
 
-func (r *UserRepository) Delete(id int) error {
-	return r.DB.Delete(&entity.User{}, id).Error
+func (r *UserRepository) Delete(ctx context.Context, id int) error {
+	return r.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.User{}).Where("id = ?", id).
+			Update("status", entity.UserStatusDeleted).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.User{}, id).Error
+	})
 }
 
-func (r *UserRepository) GetUserList(page, limit int, search string) ([]*entity.User, int64, error) {
+func (r *UserRepository) GetUserList(ctx context.Context, page, limit int, search string) ([]*entity.User, int64, error) {
 	var users []*entity.User
 	var total int64
 
-	query := r.DB.Model(&entity.User{})
+	query := r.DB.WithContext(ctx).Model(&entity.User{})
 
 	// Apply search filter if provided
 	if search != "" {
-		searchPattern := "%" + search + "%"
-		query = query.Where("username LIKE ? OR name LIKE ? OR email LIKE ?",
+		like := caseInsensitiveLike(r.DB) + " ? ESCAPE '" + likeEscape + "'"
+		searchPattern := containsPattern(search)
+		query = query.Where("username "+like+" OR name "+like+" OR email "+like,
 			searchPattern, searchPattern, searchPattern)
 	}
 
@@ -70,26 +144,32 @@ func (r *UserRepository) GetUserList(page, limit int, search string) ([]*entity.
 	return users, total, nil
 }
 
-func (r *UserRepository) ExistsByUsername(username string) (bool, error) {
+func (r *UserRepository) CountByRole(ctx context.Context, roleID uint) (int64, error) {
+	var count int64
+	err := r.DB.WithContext(ctx).Model(&entity.User{}).Where("role_id = ?", roleID).Count(&count).Error
+	return count, err
+}
+
+func (r *UserRepository) ExistsByUsername(ctx context.Context, username string) (bool, error) {
 	var count int64
-	err := r.DB.Model(&entity.User{}).Where("username = ?", username).Count(&count).Error
+	err := r.DB.WithContext(ctx).Model(&entity.User{}).Where("username_normalized = ?", entity.NormalizeUsername(username)).Count(&count).Error
 	return count > 0, err
 }
 
-func (r *UserRepository) ExistsByEmail(email string) (bool, error) {
+func (r *UserRepository) ExistsByEmail(ctx context.Context, email string) (bool, error) {
 	var count int64
-	err := r.DB.Model(&entity.User{}).Where("email = ?", email).Count(&count).Error
+	err := r.DB.WithContext(ctx).Model(&entity.User{}).Where("email_normalized = ?", entity.NormalizeEmail(email)).Count(&count).Error
 	return count > 0, err
 }
 
-func (r *UserRepository) ExistsByUsernameExcludeID(username string, excludeID uint) (bool, error) {
+func (r *UserRepository) ExistsByUsernameExcludeID(ctx context.Context, username string, excludeID uint) (bool, error) {
 	var count int64
-	err := r.DB.Model(&entity.User{}).Where("username = ? AND id != ?", username, excludeID).Count(&count).Error
+	err := r.DB.WithContext(ctx).Model(&entity.User{}).Where("username_normalized = ? AND id != ?", entity.NormalizeUsername(username), excludeID).Count(&count).Error
 	return count > 0, err
 }
 
-func (r *UserRepository) ExistsByEmailExcludeID(email string, excludeID uint) (bool, error) {
+func (r *UserRepository) ExistsByEmailExcludeID(ctx context.Context, email string, excludeID uint) (bool, error) {
 	var count int64
-	err := r.DB.Model(&entity.User{}).Where("email = ? AND id != ?", email, excludeID).Count(&count).Error
+	err := r.DB.WithContext(ctx).Model(&entity.User{}).Where("email_normalized = ? AND id != ?", entity.NormalizeEmail(email), excludeID).Count(&count).Error
 	return count > 0, err
 }
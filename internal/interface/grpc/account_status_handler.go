@@ -0,0 +1,76 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/usecase"
+	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
+	"gorm.io/gorm"
+)
+
+func (h *UserHandler) SuspendUser(ctx context.Context, req *userpb.SuspendUserRequest) (*userpb.GetUserResponse, error) {
+	if req.UserId <= 0 {
+		return nil, NewValidationError("User ID must be positive")
+	}
+
+	var expiresAt *time.Time
+	if value := strings.TrimSpace(req.ExpiresAt); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, NewValidationError(MsgInvalidExpiry)
+		}
+		expiresAt = &parsed
+	}
+
+	user, err := h.UserUseCase.SuspendUser(ctx, int(req.UserId), strings.TrimSpace(req.Reason), expiresAt, req.Lock)
+	if err != nil {
+		if authErr := authorizationError(err); authErr != nil {
+			return nil, authErr
+		}
+		if errors.Is(err, usecase.ErrSuspendSelf) {
+			return nil, NewFailedPreconditionError(MsgCannotSuspendSelf)
+		}
+		if errors.Is(err, usecase.ErrInvalidStatusExpiry) {
+			return nil, NewValidationError(MsgInvalidExpiry)
+		}
+		return nil, accountStatusError(err)
+	}
+
+	return &userpb.GetUserResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgUserSuspended,
+		Data:    toUserData(user),
+	}, nil
+}
+
+func (h *UserHandler) ReactivateUser(ctx context.Context, req *userpb.ReactivateUserRequest) (*userpb.GetUserResponse, error) {
+	if req.UserId <= 0 {
+		return nil, NewValidationError("User ID must be positive")
+	}
+
+	user, err := h.UserUseCase.ReactivateUser(ctx, int(req.UserId), strings.TrimSpace(req.Reason))
+	if err != nil {
+		if authErr := authorizationError(err); authErr != nil {
+			return nil, authErr
+		}
+		return nil, accountStatusError(err)
+	}
+
+	return &userpb.GetUserResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgUserReactivated,
+		Data:    toUserData(user),
+	}, nil
+}
+
+func accountStatusError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return NewNotFoundError(MsgUserNotFound)
+	}
+	return NewInternalError(MsgUserStatusFailed)
+}
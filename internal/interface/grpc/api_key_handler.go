@@ -0,0 +1,118 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/usecase"
+	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
+	"gorm.io/gorm"
+)
+
+func (h *UserHandler) CreateApiKey(ctx context.Context, req *userpb.CreateApiKeyRequest) (*userpb.CreateApiKeyResponse, error) {
+	if req.UserId < 0 {
+		return nil, NewValidationError("User ID must not be negative")
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, NewValidationError(MsgAPIKeyNameRequired)
+	}
+	if len(req.Scopes) == 0 {
+		return nil, NewValidationError(MsgScopesRequired)
+	}
+
+	var expiresAt *time.Time
+	if value := strings.TrimSpace(req.ExpiresAt); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return nil, NewValidationError(MsgInvalidExpiry)
+		}
+		expiresAt = &parsed
+	}
+
+	key, plaintext, err := h.APIKeyUseCase.CreateAPIKey(ctx, uint(req.UserId), name, req.Scopes, expiresAt)
+	if err != nil {
+		return nil, apiKeyError(err)
+	}
+
+	return &userpb.CreateApiKeyResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgAPIKeyCreated,
+		Data:    toAPIKeyData(key),
+		Key:     plaintext,
+	}, nil
+}
+
+func (h *UserHandler) ListApiKeys(ctx context.Context, req *userpb.ListApiKeysRequest) (*userpb.ListApiKeysResponse, error) {
+	if req.UserId < 0 {
+		return nil, NewValidationError("User ID must not be negative")
+	}
+
+	keys, err := h.APIKeyUseCase.ListAPIKeys(ctx, uint(req.UserId))
+	if err != nil {
+		return nil, apiKeyError(err)
+	}
+
+	var data []*userpb.ApiKeyData
+	for _, key := range keys {
+		data = append(data, toAPIKeyData(key))
+	}
+
+	return &userpb.ListApiKeysResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgAPIKeysRetrieved,
+		Data:    data,
+	}, nil
+}
+
+func (h *UserHandler) RevokeApiKey(ctx context.Context, req *userpb.RevokeApiKeyRequest) (*userpb.RevokeApiKeyResponse, error) {
+	if req.Id <= 0 {
+		return nil, NewValidationError("API key ID must be positive")
+	}
+
+	if err := h.APIKeyUseCase.RevokeAPIKey(ctx, uint(req.Id)); err != nil {
+		return nil, apiKeyError(err)
+	}
+
+	return &userpb.RevokeApiKeyResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgAPIKeyRevoked,
+	}, nil
+}
+
+func apiKeyError(err error) error {
+	if authErr := authorizationError(err); authErr != nil {
+		return authErr
+	}
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return NewNotFoundError(MsgAPIKeyNotFound)
+	case errors.Is(err, usecase.ErrUnknownPermission):
+		return NewValidationError(MsgUnknownPermission)
+	case errors.Is(err, usecase.ErrScopeNotGranted):
+		return NewValidationError(MsgScopeNotGranted)
+	case errors.Is(err, usecase.ErrInvalidAPIKeyExpiry):
+		return NewValidationError(MsgInvalidExpiry)
+	}
+	return NewInternalError(MsgAPIKeyOperationFailed)
+}
+
+func toAPIKeyData(key *entity.APIKey) *userpb.ApiKeyData {
+	return &userpb.ApiKeyData{
+		Id:         int32(key.ID),
+		UserId:     int32(key.UserID),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.ScopeNames(),
+		ExpiresAt:  formatTime(key.ExpiresAt),
+		LastUsedAt: formatTime(key.LastUsedAt),
+		RevokedAt:  formatTime(key.RevokedAt),
+		CreatedAt:  key.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,143 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/usecase"
+	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// PublicMethods can be called without an access token.
+var PublicMethods = []string{
+	userpb.UserService_Register_FullMethodName,
+	userpb.UserService_Login_FullMethodName,
+	userpb.UserService_VerifyMFA_FullMethodName,
+	userpb.UserService_LoginWithOIDC_FullMethodName,
+	userpb.UserService_RefreshToken_FullMethodName,
+	userpb.UserService_RequestPasswordReset_FullMethodName,
+	userpb.UserService_ResetPassword_FullMethodName,
+	userpb.UserService_VerifyEmail_FullMethodName,
+	userpb.UserService_ResendVerification_FullMethodName,
+}
+
+// Authenticator turns a bearer credential into a principal.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*usecase.Principal, error)
+}
+
+// AuthInterceptor authenticates every non-public call once, from the
+// "authorization: Bearer <token>" metadata, and stores the principal on the
+// request context for the handlers and usecases.
+type AuthInterceptor struct {
+	authenticator Authenticator
+	publicMethods map[string]bool
+}
+
+func NewAuthInterceptor(authenticator Authenticator, publicMethods []string) *AuthInterceptor {
+	public := make(map[string]bool, len(publicMethods))
+	for _, method := range publicMethods {
+		public[method] = true
+	}
+	return &AuthInterceptor{authenticator: authenticator, publicMethods: public}
+}
+
+// legacyTokenRequest is implemented by request messages that still carry the
+// deprecated in-body token field.
+type legacyTokenRequest interface {
+	GetToken() string
+}
+
+func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if i.publicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		token := bearerTokenFromMetadata(ctx)
+		if token == "" {
+			// Deprecated: fall back to the token field in the request body
+			// until all clients send the authorization header.
+			// IntrospectToken carries the token asked about, not the caller's.
+			if legacy, ok := req.(legacyTokenRequest); ok && info.FullMethod != userpb.UserService_IntrospectToken_FullMethodName {
+				token = strings.TrimSpace(legacy.GetToken())
+			}
+		}
+
+		ctx, err := i.authenticate(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if i.publicMethods[info.FullMethod] {
+			return handler(srv, ss)
+		}
+
+		ctx, err := i.authenticate(ss.Context(), bearerTokenFromMetadata(ss.Context()))
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func (i *AuthInterceptor) authenticate(ctx context.Context, token string) (context.Context, error) {
+	if token == "" {
+		return nil, NewAuthenticationError(MsgTokenRequired)
+	}
+	principal, err := i.authenticator.Authenticate(ctx, token)
+	if err != nil {
+		if isTokenError(err) {
+			return nil, NewAuthenticationError(MsgInvalidToken)
+		}
+		if inactive, ok := usecase.IsAccountInactive(err); ok {
+			return nil, NewAccountInactiveError(inactive)
+		}
+		return nil, NewInternalError(MsgInternalError)
+	}
+	return usecase.WithPrincipal(ctx, principal), nil
+}
+
+func bearerTokenFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, value := range md.Get("authorization") {
+		scheme, token, found := strings.Cut(strings.TrimSpace(value), " ")
+		if found && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	return ""
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// authorizationError maps usecase authorization failures to gRPC errors and
+// returns nil for any other error. ErrUnauthenticated only happens when the
+// interceptor is not installed for a method.
+func authorizationError(err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrUnauthenticated):
+		return NewAuthenticationError(MsgTokenRequired)
+	case errors.Is(err, usecase.ErrPermissionDenied):
+		return NewAuthorizationError(MsgPermissionDenied)
+	}
+	return nil
+}
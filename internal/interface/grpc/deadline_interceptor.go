@@ -0,0 +1,66 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// DeadlineInterceptor gives every call a deadline, unless the client's is
+// sooner, so that a slow database cannot pile up requests. Calls that fail
+// once their context is done are reported as Canceled or DeadlineExceeded,
+// whatever error the handler made of it.
+type DeadlineInterceptor struct {
+	timeout  time.Duration
+	timeouts map[string]time.Duration
+}
+
+// NewDeadlineInterceptor applies timeout to all methods but those in
+// timeouts, which is keyed by method name, e.g. "Login". A zero timeout
+// leaves the deadline to the client.
+func NewDeadlineInterceptor(timeout time.Duration, timeouts map[string]time.Duration) (*DeadlineInterceptor, error) {
+	for name := range timeouts {
+		if !isUserServiceMethod(name) {
+			return nil, fmt.Errorf("timeout for unknown method %q", name)
+		}
+	}
+	return &DeadlineInterceptor{timeout: timeout, timeouts: timeouts}, nil
+}
+
+func (i *DeadlineInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if timeout := i.timeoutFor(info.FullMethod); timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil && ctx.Err() != nil {
+			return nil, status.FromContextError(ctx.Err()).Err()
+		}
+		return resp, err
+	}
+}
+
+func (i *DeadlineInterceptor) timeoutFor(fullMethod string) time.Duration {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	if timeout, ok := i.timeouts[name]; ok {
+		return timeout
+	}
+	return i.timeout
+}
+
+func isUserServiceMethod(name string) bool {
+	for _, method := range userpb.UserService_ServiceDesc.Methods {
+		if method.MethodName == name {
+			return true
+		}
+	}
+	return false
+}
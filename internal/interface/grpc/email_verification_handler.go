@@ -0,0 +1,47 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/usecase"
+	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
+)
+
+func (h *UserHandler) VerifyEmail(ctx context.Context, req *userpb.VerifyEmailRequest) (*userpb.VerifyEmailResponse, error) {
+	if strings.TrimSpace(req.Token) == "" {
+		return nil, NewValidationError(MsgVerifyTokenRequired)
+	}
+
+	if _, err := h.EmailVerificationUseCase.VerifyEmail(ctx, req.Token); err != nil {
+		if errors.Is(err, usecase.ErrInvalidVerifyToken) {
+			return nil, NewValidationError(MsgInvalidVerifyToken)
+		}
+		return nil, NewInternalError(MsgEmailVerificationFailed)
+	}
+
+	return &userpb.VerifyEmailResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgEmailVerified,
+	}, nil
+}
+
+func (h *UserHandler) ResendVerification(ctx context.Context, req *userpb.ResendVerificationRequest) (*userpb.VerifyEmailResponse, error) {
+	email := strings.TrimSpace(req.Email)
+	if email == "" {
+		return nil, NewValidationError(MsgEmailRequired)
+	}
+
+	// The answer must not reveal whether the email belongs to an account
+	if err := h.EmailVerificationUseCase.ResendVerification(ctx, email); err != nil {
+		return nil, NewInternalError(MsgEmailVerificationFailed)
+	}
+
+	return &userpb.VerifyEmailResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgVerificationSent,
+	}, nil
+}
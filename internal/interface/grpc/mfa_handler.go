@@ -0,0 +1,121 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/usecase"
+	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
+)
+
+func (h *UserHandler) VerifyMFA(ctx context.Context, req *userpb.VerifyMFARequest) (*userpb.AuthResponse, error) {
+	if strings.TrimSpace(req.MfaToken) == "" {
+		return nil, NewValidationError(MsgMFATokenRequired)
+	}
+	if strings.TrimSpace(req.Code) == "" {
+		return nil, NewValidationError(MsgMFACodeRequired)
+	}
+
+	tokens, err := h.UserUseCase.VerifyMFA(ctx, req.MfaToken, req.Code, clientInfo(ctx))
+	if err != nil {
+		if throttled, ok := usecase.IsLoginThrottled(err); ok {
+			return nil, NewLoginThrottledError(throttled.RetryAfter, throttled.Locked)
+		}
+		if errors.Is(err, usecase.ErrInvalidMFAToken) {
+			return nil, NewAuthenticationError(MsgInvalidMFAToken)
+		}
+		if inactive, ok := usecase.IsAccountInactive(err); ok {
+			return nil, NewAccountInactiveError(inactive)
+		}
+		return nil, mfaError(err)
+	}
+
+	return newAuthResponse(MsgUserLoggedIn, tokens), nil
+}
+
+func (h *UserHandler) EnrollTOTP(ctx context.Context, req *userpb.EnrollTOTPRequest) (*userpb.EnrollTOTPResponse, error) {
+	enrollment, err := h.MFAUseCase.EnrollTOTP(ctx)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &userpb.EnrollTOTPResponse{
+		Success:    true,
+		Code:       string(CodeSuccess),
+		Message:    MsgTOTPEnrolled,
+		Secret:     enrollment.Secret,
+		OtpauthUri: enrollment.URI,
+	}, nil
+}
+
+func (h *UserHandler) ConfirmTOTP(ctx context.Context, req *userpb.ConfirmTOTPRequest) (*userpb.RecoveryCodesResponse, error) {
+	if strings.TrimSpace(req.Code) == "" {
+		return nil, NewValidationError(MsgMFACodeRequired)
+	}
+
+	codes, err := h.MFAUseCase.ConfirmTOTP(ctx, req.Code)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &userpb.RecoveryCodesResponse{
+		Success:       true,
+		Code:          string(CodeSuccess),
+		Message:       MsgTOTPEnabled,
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func (h *UserHandler) DisableTOTP(ctx context.Context, req *userpb.DisableTOTPRequest) (*userpb.MFAResponse, error) {
+	if req.UserId < 0 {
+		return nil, NewValidationError("User ID must be positive")
+	}
+
+	if err := h.MFAUseCase.DisableTOTP(ctx, uint(req.UserId), req.Code); err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &userpb.MFAResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgTOTPDisabled,
+	}, nil
+}
+
+func (h *UserHandler) RegenerateRecoveryCodes(ctx context.Context, req *userpb.RegenerateRecoveryCodesRequest) (*userpb.RecoveryCodesResponse, error) {
+	if strings.TrimSpace(req.Code) == "" {
+		return nil, NewValidationError(MsgMFACodeRequired)
+	}
+
+	codes, err := h.MFAUseCase.RegenerateRecoveryCodes(ctx, req.Code)
+	if err != nil {
+		return nil, mfaError(err)
+	}
+
+	return &userpb.RecoveryCodesResponse{
+		Success:       true,
+		Code:          string(CodeSuccess),
+		Message:       MsgRecoveryCodesRegenerated,
+		RecoveryCodes: codes,
+	}, nil
+}
+
+func mfaError(err error) error {
+	if authErr := authorizationError(err); authErr != nil {
+		return authErr
+	}
+	switch {
+	case errors.Is(err, usecase.ErrInvalidMFACode):
+		return NewAuthenticationError(MsgInvalidMFACode)
+	case errors.Is(err, usecase.ErrMFAUnavailable):
+		return NewFailedPreconditionError(MsgMFAUnavailable)
+	case errors.Is(err, usecase.ErrTOTPAlreadyEnabled):
+		return NewFailedPreconditionError(MsgTOTPAlreadyEnabled)
+	case errors.Is(err, usecase.ErrTOTPNotEnrolled):
+		return NewFailedPreconditionError(MsgTOTPNotEnrolled)
+	case errors.Is(err, usecase.ErrTOTPNotEnabled):
+		return NewFailedPreconditionError(MsgTOTPNotEnabled)
+	}
+	return NewInternalError(MsgMFAOperationFailed)
+}
@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/usecase"
+	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
+	"gorm.io/gorm"
+)
+
+func (h *UserHandler) CreateOAuthClient(ctx context.Context, req *userpb.CreateOAuthClientRequest) (*userpb.CreateOAuthClientResponse, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, NewValidationError(MsgOAuthClientNameRequired)
+	}
+	if len(req.GrantTypes) == 0 {
+		return nil, NewValidationError(MsgGrantTypesRequired)
+	}
+	if len(req.Scopes) == 0 {
+		return nil, NewValidationError(MsgScopesRequired)
+	}
+	for _, scope := range req.Scopes {
+		if scope == "" || strings.ContainsAny(scope, " \t\r\n") {
+			return nil, NewValidationError(MsgInvalidOAuthScope)
+		}
+	}
+
+	client, secret, err := h.OAuthUseCase.CreateClient(ctx, usecase.OAuthClientRegistration{
+		Name:         name,
+		RedirectURIs: req.RedirectUris,
+		GrantTypes:   req.GrantTypes,
+		Scopes:       req.Scopes,
+		Public:       req.Public,
+	})
+	if err != nil {
+		return nil, oauthClientError(err)
+	}
+
+	return &userpb.CreateOAuthClientResponse{
+		Success:      true,
+		Code:         string(CodeSuccess),
+		Message:      MsgOAuthClientCreated,
+		Data:         toOAuthClientData(client),
+		ClientSecret: secret,
+	}, nil
+}
+
+func (h *UserHandler) ListOAuthClients(ctx context.Context, req *userpb.ListOAuthClientsRequest) (*userpb.ListOAuthClientsResponse, error) {
+	clients, err := h.OAuthUseCase.ListClients(ctx)
+	if err != nil {
+		return nil, oauthClientError(err)
+	}
+
+	var data []*userpb.OAuthClientData
+	for _, client := range clients {
+		data = append(data, toOAuthClientData(client))
+	}
+
+	return &userpb.ListOAuthClientsResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgOAuthClientsRetrieved,
+		Data:    data,
+	}, nil
+}
+
+func (h *UserHandler) DeleteOAuthClient(ctx context.Context, req *userpb.DeleteOAuthClientRequest) (*userpb.DeleteOAuthClientResponse, error) {
+	if req.Id <= 0 {
+		return nil, NewValidationError("OAuth client ID must be positive")
+	}
+
+	if err := h.OAuthUseCase.DeleteClient(ctx, uint(req.Id)); err != nil {
+		return nil, oauthClientError(err)
+	}
+
+	return &userpb.DeleteOAuthClientResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgOAuthClientDeleted,
+	}, nil
+}
+
+func oauthClientError(err error) error {
+	if authErr := authorizationError(err); authErr != nil {
+		return authErr
+	}
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return NewNotFoundError(MsgOAuthClientNotFound)
+	case errors.Is(err, usecase.ErrUnknownGrantType):
+		return NewValidationError(MsgUnknownGrantType)
+	case errors.Is(err, usecase.ErrInvalidRedirectURI):
+		return NewValidationError(MsgInvalidRedirectURI)
+	case errors.Is(err, usecase.ErrPublicClientCredentials):
+		return NewValidationError(MsgPublicClientCredentials)
+	}
+	return NewInternalError(MsgOAuthClientOperationFailed)
+}
+
+func toOAuthClientData(client *entity.OAuthClient) *userpb.OAuthClientData {
+	return &userpb.OAuthClientData{
+		Id:           int32(client.ID),
+		ClientId:     client.ClientID,
+		Name:         client.Name,
+		RedirectUris: client.RedirectURIs,
+		GrantTypes:   client.GrantTypes,
+		Scopes:       client.Scopes,
+		Public:       client.Public(),
+		CreatedAt:    client.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/usecase"
+	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
+)
+
+func (h *UserHandler) LoginWithOIDC(ctx context.Context, req *userpb.LoginWithOIDCRequest) (*userpb.AuthResponse, error) {
+	if strings.TrimSpace(req.Provider) == "" {
+		return nil, NewValidationError(MsgOIDCProviderRequired)
+	}
+	if strings.TrimSpace(req.Code) == "" {
+		return nil, NewValidationError(MsgAuthorizationCodeRequired)
+	}
+
+	tokens, challenge, err := h.OIDCLoginUseCase.LoginWithOIDC(ctx, req.Provider, req.Code, req.CodeVerifier, req.Nonce, clientInfo(ctx))
+	if err != nil {
+		if busyErr := overloadError(err); busyErr != nil {
+			return nil, busyErr
+		}
+		if errors.Is(err, usecase.ErrUnknownOIDCProvider) {
+			return nil, NewValidationError(MsgUnknownOIDCProvider)
+		}
+		if errors.Is(err, usecase.ErrOIDCLoginRejected) {
+			return nil, NewAuthenticationError(MsgOIDCLoginRejected)
+		}
+		if errors.Is(err, usecase.ErrEmailExists) {
+			return nil, NewAlreadyExistsError(MsgOIDCEmailExists)
+		}
+		if inactive, ok := usecase.IsAccountInactive(err); ok {
+			return nil, NewAccountInactiveError(inactive)
+		}
+		if errors.Is(err, usecase.ErrEmailNotVerified) {
+			return nil, NewFailedPreconditionError(MsgEmailNotVerified)
+		}
+		return nil, NewInternalError(MsgOIDCLoginFailed)
+	}
+
+	if challenge != nil {
+		return &userpb.AuthResponse{
+			Success:           true,
+			Code:              string(CodeSuccess),
+			Message:           MsgMFARequired,
+			MfaRequired:       true,
+			MfaToken:          challenge.Token,
+			MfaTokenExpiresAt: challenge.ExpiresAt.Format(time.RFC3339),
+		}, nil
+	}
+	return newAuthResponse(MsgUserLoggedIn, tokens), nil
+}
@@ -0,0 +1,80 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/usecase"
+	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
+	"gorm.io/gorm"
+)
+
+func (h *UserHandler) ChangePassword(ctx context.Context, req *userpb.ChangePasswordRequest) (*userpb.AuthResponse, error) {
+	if req.CurrentPassword == "" {
+		return nil, NewValidationError(MsgCurrentPasswordRequired)
+	}
+	if strings.TrimSpace(req.NewPassword) == "" {
+		return nil, NewValidationError(MsgPasswordRequired)
+	}
+
+	tokens, err := h.UserUseCase.ChangePassword(ctx, req.CurrentPassword, req.NewPassword, clientInfo(ctx))
+	if err != nil {
+		if authErr := authorizationError(err); authErr != nil {
+			return nil, authErr
+		}
+		if throttled, ok := usecase.IsLoginThrottled(err); ok {
+			return nil, NewLoginThrottledError(throttled.RetryAfter, throttled.Locked)
+		}
+		if busyErr := overloadError(err); busyErr != nil {
+			return nil, busyErr
+		}
+		if errors.Is(err, usecase.ErrInvalidCurrentPassword) {
+			return nil, NewValidationError(MsgInvalidCurrentPassword)
+		}
+		if policyErr, ok := usecase.IsPasswordPolicyViolation(err); ok {
+			return nil, NewPasswordPolicyError("new_password", policyErr.Violations)
+		}
+		if errors.Is(err, infrastructure.ErrPasswordTooLong) {
+			return nil, NewValidationError(MsgPasswordTooLong)
+		}
+		return nil, NewInternalError(MsgPasswordChangeFailed)
+	}
+
+	return newAuthResponse(MsgPasswordChanged, tokens), nil
+}
+
+func (h *UserHandler) SetPassword(ctx context.Context, req *userpb.SetPasswordRequest) (*userpb.SetPasswordResponse, error) {
+	if req.UserId <= 0 {
+		return nil, NewValidationError("User ID must be positive")
+	}
+	if strings.TrimSpace(req.NewPassword) == "" {
+		return nil, NewValidationError(MsgPasswordRequired)
+	}
+
+	if err := h.UserUseCase.SetPassword(ctx, int(req.UserId), req.NewPassword, req.RequireChange); err != nil {
+		if authErr := authorizationError(err); authErr != nil {
+			return nil, authErr
+		}
+		if busyErr := overloadError(err); busyErr != nil {
+			return nil, busyErr
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, NewNotFoundError(MsgUserNotFound)
+		}
+		if policyErr, ok := usecase.IsPasswordPolicyViolation(err); ok {
+			return nil, NewPasswordPolicyError("new_password", policyErr.Violations)
+		}
+		if errors.Is(err, infrastructure.ErrPasswordTooLong) {
+			return nil, NewValidationError(MsgPasswordTooLong)
+		}
+		return nil, NewInternalError(MsgPasswordChangeFailed)
+	}
+
+	return &userpb.SetPasswordResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgPasswordSet,
+	}, nil
+}
@@ -0,0 +1,63 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/usecase"
+	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
+)
+
+func (h *UserHandler) RequestPasswordReset(ctx context.Context, req *userpb.RequestPasswordResetRequest) (*userpb.PasswordResetResponse, error) {
+	email := strings.TrimSpace(req.Email)
+	if email == "" {
+		return nil, NewValidationError(MsgEmailRequired)
+	}
+
+	// The answer must not reveal whether the email belongs to an account
+	if err := h.PasswordResetUseCase.RequestPasswordReset(ctx, email, clientInfo(ctx)); err != nil {
+		if errors.Is(err, usecase.ErrTooManyResetRequests) {
+			return nil, NewResourceExhaustedError(MsgTooManyResetRequests)
+		}
+		return nil, NewInternalError(MsgPasswordResetFailed)
+	}
+
+	return &userpb.PasswordResetResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgPasswordResetRequested,
+	}, nil
+}
+
+func (h *UserHandler) ResetPassword(ctx context.Context, req *userpb.ResetPasswordRequest) (*userpb.PasswordResetResponse, error) {
+	if strings.TrimSpace(req.Token) == "" {
+		return nil, NewValidationError(MsgResetTokenRequired)
+	}
+	if strings.TrimSpace(req.NewPassword) == "" {
+		return nil, NewValidationError(MsgPasswordRequired)
+	}
+
+	if err := h.PasswordResetUseCase.ResetPassword(ctx, req.Token, req.NewPassword, clientInfo(ctx)); err != nil {
+		if busyErr := overloadError(err); busyErr != nil {
+			return nil, busyErr
+		}
+		if errors.Is(err, usecase.ErrInvalidResetToken) {
+			return nil, NewValidationError(MsgInvalidResetToken)
+		}
+		if policyErr, ok := usecase.IsPasswordPolicyViolation(err); ok {
+			return nil, NewPasswordPolicyError("new_password", policyErr.Violations)
+		}
+		if errors.Is(err, infrastructure.ErrPasswordTooLong) {
+			return nil, NewValidationError(MsgPasswordTooLong)
+		}
+		return nil, NewInternalError(MsgPasswordResetFailed)
+	}
+
+	return &userpb.PasswordResetResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgPasswordReset,
+	}, nil
+}
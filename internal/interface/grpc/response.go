@@ -1,50 +1,166 @@
 package grpc
 
 import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/usecase"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // Response messages for consistency
 const (
 	// Success messages
-	MsgUserRegistered    = "User registered successfully"
-	MsgUserLoggedIn      = "User logged in successfully"
-	MsgProfileRetrieved  = "User profile retrieved successfully"
-	MsgUserListRetrieved = "User list retrieved successfully"
-	MsgUserRetrieved     = "User retrieved successfully"
-	MsgUserCreated       = "User created successfully"
-	MsgUserUpdated       = "User updated successfully"
-	MsgUserDeleted       = "User deleted successfully"
+	MsgUserRegistered            = "User registered successfully"
+	MsgUserLoggedIn              = "User logged in successfully"
+	MsgMFARequired               = "Two-factor authentication required"
+	MsgTokenRefreshed            = "Token refreshed successfully"
+	MsgUserLoggedOut             = "User logged out successfully"
+	MsgSessionsRevoked           = "All sessions revoked successfully"
+	MsgTokenIntrospected         = "Token introspected successfully"
+	MsgRolesRetrieved            = "Roles retrieved successfully"
+	MsgRoleCreated               = "Role created successfully"
+	MsgRoleUpdated               = "Role updated successfully"
+	MsgRoleDeleted               = "Role deleted successfully"
+	MsgPermissionsRetrieved      = "Permissions retrieved successfully"
+	MsgRoleAssigned              = "Role assigned successfully"
+	MsgUserUnlocked              = "User unlocked successfully"
+	MsgProfileRetrieved          = "User profile retrieved successfully"
+	MsgUserListRetrieved         = "User list retrieved successfully"
+	MsgUserRetrieved             = "User retrieved successfully"
+	MsgUserCreated               = "User created successfully"
+	MsgUserUpdated               = "User updated successfully"
+	MsgUserDeleted               = "User deleted successfully"
+	MsgPasswordResetRequested    = "If an account with that verified email exists, a password reset link has been sent"
+	MsgPasswordReset             = "Password has been reset successfully"
+	MsgUserRegisteredVerifyEmail = "User registered successfully; verify your email address to sign in"
+	MsgEmailVerified             = "Email address verified successfully"
+	MsgVerificationSent          = "If an unverified account with that email exists, a verification link has been sent"
+	MsgTOTPEnrolled              = "Scan the secret with an authenticator app and confirm it with a code"
+	MsgTOTPEnabled               = "Two-factor authentication enabled"
+	MsgTOTPDisabled              = "Two-factor authentication disabled"
+	MsgRecoveryCodesRegenerated  = "Recovery codes regenerated"
+	MsgPasswordChanged           = "Password changed successfully; other sessions were signed out"
+	MsgPasswordSet               = "Password set successfully"
+	MsgUserSuspended             = "User suspended successfully"
+	MsgUserReactivated           = "User reactivated successfully"
+	MsgAPIKeyCreated             = "API key created; store it now, it will not be shown again"
+	MsgAPIKeysRetrieved          = "API keys retrieved successfully"
+	MsgAPIKeyRevoked             = "API key revoked successfully"
+	MsgSessionsRetrieved         = "Sessions retrieved successfully"
+	MsgSessionRevoked            = "Session revoked successfully"
+	MsgOAuthClientCreated        = "OAuth client created; store the secret now, it will not be shown again"
+	MsgOAuthClientsRetrieved     = "OAuth clients retrieved successfully"
+	MsgOAuthClientDeleted        = "OAuth client deleted successfully"
 
 	// Error messages - Validation
-	MsgUsernameRequired = "Username is required"
-	MsgNameRequired     = "Name is required"
-	MsgEmailRequired    = "Email is required"
-	MsgPasswordRequired = "Password is required"
-	MsgTokenRequired    = "Authentication token is required"
-	MsgInvalidEmail     = "Invalid email format"
-	MsgInvalidUsername  = "Username must be 3-30 characters and contain only letters, numbers, and underscores"
-	MsgPasswordTooShort = "Password must be at least 6 characters long"
-	MsgInvalidRoleID    = "Role ID must be a positive integer"
-	MsgUsernameExists   = "Username already exists"
-	MsgEmailExists      = "Email address already exists"
+	MsgUsernameRequired     = "Username is required"
+	MsgIdentifierRequired   = "Username or email address is required"
+	MsgNameRequired         = "Name is required"
+	MsgEmailRequired        = "Email is required"
+	MsgPasswordRequired     = "Password is required"
+	MsgTokenRequired        = "Authentication token is required"
+	MsgRefreshTokenRequired = "Refresh token is required"
+	MsgInvalidEmail         = "Invalid email format"
+	MsgInvalidIP            = "Invalid IP address"
+	MsgInvalidUsername      = "Username must be 3-30 characters and contain only letters, numbers, and underscores"
+	MsgPasswordTooLong      = "Password is too long"
+	MsgPasswordPolicy       = "Password does not meet the password policy"
+	MsgInvalidRoleID        = "Role ID must be a positive integer"
+	MsgUsernameExists       = "Username already exists"
+	MsgEmailExists          = "Email address already exists"
+	MsgRoleNameRequired     = "Role name is required"
+	MsgRoleExists           = "Role name already exists"
+	MsgUnknownPermission    = "One or more permissions do not exist"
+	MsgResetTokenRequired   = "Reset token is required"
+	MsgVerifyTokenRequired  = "Verification token is required"
+	MsgMFATokenRequired     = "MFA token is required"
+	MsgMFACodeRequired      = "Verification code is required"
+	MsgInvalidExpiry        = "Expiry must be a future RFC 3339 timestamp"
+	MsgAPIKeyNameRequired   = "API key name is required"
+	MsgScopesRequired       = "At least one scope is required"
+	MsgScopeNotGranted      = "One or more scopes are not granted to the key's user"
+	MsgGrantTypesRequired   = "At least one grant type is required"
+	MsgUnknownGrantType     = "Grant types must be authorization_code, refresh_token or client_credentials"
+	MsgInvalidRedirectURI   = "Redirect URIs must be https, or http on a loopback address, without a fragment, and the authorization code grant needs one"
+	MsgInvalidOAuthScope    = "Scopes must be non-empty and contain no spaces"
+	MsgOIDCProviderRequired = "Identity provider is required"
+	MsgUnknownOIDCProvider  = "Unknown identity provider"
+
+	MsgCurrentPasswordRequired   = "Current password is required"
+	MsgOAuthClientNameRequired   = "OAuth client name is required"
+	MsgPublicClientCredentials   = "Public clients cannot use the client credentials grant"
+	MsgAuthorizationCodeRequired = "Authorization code is required"
 
 	// Error messages - Authentication/Authorization
-	MsgInvalidCredentials = "Invalid username or password"
-	MsgInvalidToken       = "Invalid or expired token"
-	MsgUnauthorized       = "Unauthorized access"
+	MsgInvalidCredentials  = "Invalid username or password"
+	MsgInvalidToken        = "Invalid or expired token"
+	MsgUnauthorized        = "Unauthorized access"
+	MsgPermissionDenied    = "You do not have permission to perform this action"
+	MsgLoginThrottled      = "Too many failed login attempts, please retry later"
+	MsgAccountLocked       = "Account temporarily locked after too many failed login attempts"
+	MsgInvalidRefreshToken = "Invalid or expired refresh token"
+	MsgRefreshTokenReused  = "Refresh token has already been used; all sessions from this login were revoked"
+	MsgInvalidResetToken   = "Invalid or expired password reset token"
+	MsgInvalidVerifyToken  = "Invalid or expired email verification token"
+	MsgEmailNotVerified    = "Email address must be verified before signing in"
+	MsgInvalidMFACode      = "Invalid verification code"
+	MsgInvalidMFAToken     = "Invalid or expired MFA token"
+	MsgAccountPending      = "Account is pending activation"
+	MsgAccountSuspended    = "Account is suspended"
+	MsgAccountStatusLocked = "Account is locked"
+	MsgAccountDeleted      = "Account has been deleted"
+	MsgCannotSuspendSelf   = "You cannot suspend your own account"
+	MsgOIDCLoginRejected   = "The identity provider did not accept the sign-in"
+	MsgOIDCEmailExists     = "An account with this email address already exists; sign in with its password"
+
+	MsgInvalidCurrentPassword = "Current password is incorrect"
+	MsgTooManyResetRequests   = "Too many password reset requests, please retry later"
 
 	// Error messages - Internal/System
-	MsgUserRegistrationFailed = "Failed to register user"
-	MsgUserLoginFailed        = "Failed to authenticate user"
-	MsgProfileRetrievalFailed = "Failed to retrieve user profile"
-	MsgUserNotFound           = "User not found"
-	MsgUserCreationFailed     = "Failed to create user"
-	MsgUserUpdateFailed       = "Failed to update user"
-	MsgUserDeletionFailed     = "Failed to delete user"
-	MsgInternalError          = "Internal server error"
-	MsgDatabaseError          = "Database operation failed"
+	MsgUserRegistrationFailed   = "Failed to register user"
+	MsgUserLoginFailed          = "Failed to authenticate user"
+	MsgOIDCLoginFailed          = "Failed to sign in with the identity provider"
+	MsgTokenRefreshFailed       = "Failed to refresh token"
+	MsgLogoutFailed             = "Failed to log out"
+	MsgTokenIntrospectionFailed = "Failed to introspect token"
+	MsgProfileRetrievalFailed   = "Failed to retrieve user profile"
+	MsgUserNotFound             = "User not found"
+	MsgUserCreationFailed       = "Failed to create user"
+	MsgUserUpdateFailed         = "Failed to update user"
+	MsgUserDeletionFailed       = "Failed to delete user"
+	MsgRoleNotFound             = "Role not found"
+	MsgRoleInUse                = "Role is still assigned to users"
+	MsgBuiltInRole              = "Built-in roles cannot be modified or deleted"
+	MsgRoleOperationFailed      = "Failed to manage role"
+	MsgUserUnlockFailed         = "Failed to unlock user"
+	MsgUserStatusFailed         = "Failed to change user status"
+	MsgAPIKeyNotFound           = "API key not found"
+	MsgAPIKeyOperationFailed    = "Failed to manage API keys"
+	MsgSessionNotFound          = "Session not found"
+	MsgSessionOperationFailed   = "Failed to manage sessions"
+	MsgOAuthClientNotFound      = "OAuth client not found"
+	MsgPasswordResetFailed      = "Failed to reset password"
+	MsgPasswordChangeFailed     = "Failed to change password"
+	MsgEmailVerificationFailed  = "Failed to verify email address"
+	MsgMFAUnavailable           = "Two-factor authentication is not available"
+	MsgTOTPAlreadyEnabled       = "Two-factor authentication is already enabled"
+	MsgTOTPNotEnrolled          = "Start two-factor enrollment first"
+	MsgTOTPNotEnabled           = "Two-factor authentication is not enabled"
+	MsgMFAOperationFailed       = "Failed to manage two-factor authentication"
+	MsgServerBusy               = "Server is busy, please retry shortly"
+	MsgInternalError            = "Internal server error"
+	MsgDatabaseError            = "Database operation failed"
+
+	MsgOAuthClientOperationFailed = "Failed to manage OAuth clients"
 )
 
 // Error helper functions for consistent error responses
@@ -72,6 +188,109 @@ func NewAlreadyExistsError(message string) error {
 	return status.Errorf(codes.AlreadyExists, message)
 }
 
+func NewFailedPreconditionError(message string) error {
+	return status.Errorf(codes.FailedPrecondition, message)
+}
+
+func NewResourceExhaustedError(message string) error {
+	return status.Errorf(codes.ResourceExhausted, message)
+}
+
+// NewLoginThrottledError reports RESOURCE_EXHAUSTED with a RetryInfo detail
+// telling the client how long to wait before the next login attempt.
+func NewLoginThrottledError(retryAfter time.Duration, locked bool) error {
+	message := MsgLoginThrottled
+	if locked {
+		message = MsgAccountLocked
+	}
+	// Round up to whole seconds so clients never retry a moment too early
+	retryAfter = (retryAfter + time.Second - 1).Truncate(time.Second)
+	st, err := status.New(codes.ResourceExhausted, message).WithDetails(&errdetails.RetryInfo{
+		RetryDelay: durationpb.New(retryAfter),
+	})
+	if err != nil {
+		return status.Error(codes.ResourceExhausted, message)
+	}
+	return st.Err()
+}
+
+// accountStatusMessages holds the message for each status that blocks access.
+var accountStatusMessages = map[entity.UserStatus]string{
+	entity.UserStatusPending:   MsgAccountPending,
+	entity.UserStatusSuspended: MsgAccountSuspended,
+	entity.UserStatusLocked:    MsgAccountStatusLocked,
+	entity.UserStatusDeleted:   MsgAccountDeleted,
+}
+
+// NewAccountInactiveError reports PERMISSION_DENIED with an ErrorInfo detail
+// whose reason names the account status, e.g. ACCOUNT_SUSPENDED, so clients
+// can tell an inactive account from a missing permission.
+func NewAccountInactiveError(inactive *usecase.AccountInactiveError) error {
+	message, ok := accountStatusMessages[inactive.Status]
+	if !ok {
+		message = MsgPermissionDenied
+	}
+	metadata := map[string]string{"status": string(inactive.Status)}
+	if inactive.Reason != "" {
+		metadata["reason"] = inactive.Reason
+	}
+	if inactive.ExpiresAt != nil {
+		metadata["expires_at"] = inactive.ExpiresAt.Format(time.RFC3339)
+	}
+	st, err := status.New(codes.PermissionDenied, message).WithDetails(&errdetails.ErrorInfo{
+		Reason:   "ACCOUNT_" + strings.ToUpper(string(inactive.Status)),
+		Metadata: metadata,
+	})
+	if err != nil {
+		return status.Error(codes.PermissionDenied, message)
+	}
+	return st.Err()
+}
+
+// NewPasswordPolicyError reports INVALID_ARGUMENT with a BadRequest detail
+// holding one field violation per broken rule of the password policy.
+func NewPasswordPolicyError(field string, violations []usecase.PasswordViolation) error {
+	descriptions := make([]string, len(violations))
+	fieldViolations := make([]*errdetails.BadRequest_FieldViolation, len(violations))
+	for i, v := range violations {
+		descriptions[i] = v.Description
+		fieldViolations[i] = &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: v.Description,
+			Reason:      "PASSWORD_" + strings.ToUpper(v.Rule),
+		}
+	}
+	message := MsgPasswordPolicy + ": " + strings.Join(descriptions, "; ")
+	st, err := status.New(codes.InvalidArgument, message).WithDetails(&errdetails.BadRequest{
+		FieldViolations: fieldViolations,
+	})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, message)
+	}
+	return st.Err()
+}
+
+// serverBusyRetryDelay is suggested to clients turned away by a full queue.
+const serverBusyRetryDelay = time.Second
+
+// overloadError maps errors of requests that were turned away or gave up
+// before the work was done, or returns nil for any other error.
+func overloadError(err error) error {
+	switch {
+	case errors.Is(err, infrastructure.ErrHashingQueueFull):
+		st, detailErr := status.New(codes.ResourceExhausted, MsgServerBusy).WithDetails(&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(serverBusyRetryDelay),
+		})
+		if detailErr != nil {
+			return status.Error(codes.ResourceExhausted, MsgServerBusy)
+		}
+		return st.Err()
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	}
+	return nil
+}
+
 // Response code mapping for different scenarios
 type ResponseCode string
 
@@ -82,6 +301,8 @@ const (
 	CodeAuthorizationError  ResponseCode = "AUTHORIZATION_ERROR"
 	CodeNotFound            ResponseCode = "NOT_FOUND"
 	CodeAlreadyExists       ResponseCode = "ALREADY_EXISTS"
+	CodeFailedPrecondition  ResponseCode = "FAILED_PRECONDITION"
+	CodeResourceExhausted   ResponseCode = "RESOURCE_EXHAUSTED"
 	CodeInternalError       ResponseCode = "INTERNAL_ERROR"
 )
 
@@ -107,6 +328,10 @@ func GetResponseCode(err error) ResponseCode {
 		return CodeNotFound
 	case codes.AlreadyExists:
 		return CodeAlreadyExists
+	case codes.FailedPrecondition:
+		return CodeFailedPrecondition
+	case codes.ResourceExhausted:
+		return CodeResourceExhausted
 	case codes.Internal:
 		return CodeInternalError
 	default:
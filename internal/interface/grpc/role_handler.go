@@ -0,0 +1,172 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/usecase"
+	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
+	"gorm.io/gorm"
+)
+
+func (h *UserHandler) ListRoles(ctx context.Context, req *userpb.ListRolesRequest) (*userpb.ListRolesResponse, error) {
+	roles, err := h.RoleUseCase.ListRoles(ctx)
+	if err != nil {
+		return nil, roleError(err)
+	}
+
+	var data []*userpb.RoleData
+	for _, role := range roles {
+		data = append(data, toRoleData(role))
+	}
+
+	return &userpb.ListRolesResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgRolesRetrieved,
+		Data:    data,
+	}, nil
+}
+
+func (h *UserHandler) CreateRole(ctx context.Context, req *userpb.CreateRoleRequest) (*userpb.RoleResponse, error) {
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, NewValidationError(MsgRoleNameRequired)
+	}
+
+	role := &entity.Role{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+	}
+
+	createdRole, err := h.RoleUseCase.CreateRole(ctx, role, req.Permissions)
+	if err != nil {
+		return nil, roleError(err)
+	}
+
+	return &userpb.RoleResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgRoleCreated,
+		Data:    toRoleData(createdRole),
+	}, nil
+}
+
+func (h *UserHandler) UpdateRole(ctx context.Context, req *userpb.UpdateRoleRequest) (*userpb.RoleResponse, error) {
+	if req.RoleId <= 0 {
+		return nil, NewValidationError(MsgInvalidRoleID)
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		return nil, NewValidationError(MsgRoleNameRequired)
+	}
+
+	updateData := &entity.Role{
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+	}
+
+	updatedRole, err := h.RoleUseCase.UpdateRole(ctx, uint(req.RoleId), updateData, req.Permissions)
+	if err != nil {
+		return nil, roleError(err)
+	}
+
+	return &userpb.RoleResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgRoleUpdated,
+		Data:    toRoleData(updatedRole),
+	}, nil
+}
+
+func (h *UserHandler) DeleteRole(ctx context.Context, req *userpb.DeleteRoleRequest) (*userpb.DeleteRoleResponse, error) {
+	if req.RoleId <= 0 {
+		return nil, NewValidationError(MsgInvalidRoleID)
+	}
+
+	if err := h.RoleUseCase.DeleteRole(ctx, uint(req.RoleId)); err != nil {
+		return nil, roleError(err)
+	}
+
+	return &userpb.DeleteRoleResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgRoleDeleted,
+	}, nil
+}
+
+func (h *UserHandler) ListPermissions(ctx context.Context, req *userpb.ListPermissionsRequest) (*userpb.ListPermissionsResponse, error) {
+	permissions, err := h.RoleUseCase.ListPermissions(ctx)
+	if err != nil {
+		return nil, roleError(err)
+	}
+
+	var data []*userpb.PermissionData
+	for _, permission := range permissions {
+		data = append(data, &userpb.PermissionData{
+			Name:        permission.Name,
+			Description: permission.Description,
+		})
+	}
+
+	return &userpb.ListPermissionsResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgPermissionsRetrieved,
+		Data:    data,
+	}, nil
+}
+
+func (h *UserHandler) AssignRole(ctx context.Context, req *userpb.AssignRoleRequest) (*userpb.GetUserResponse, error) {
+	if req.UserId <= 0 {
+		return nil, NewValidationError("User ID must be positive")
+	}
+	if req.RoleId <= 0 {
+		return nil, NewValidationError(MsgInvalidRoleID)
+	}
+
+	user, err := h.RoleUseCase.AssignRole(ctx, int(req.UserId), uint(req.RoleId))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, NewNotFoundError(MsgUserNotFound)
+		}
+		return nil, roleError(err)
+	}
+
+	return &userpb.GetUserResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgRoleAssigned,
+		Data:    toUserData(user),
+	}, nil
+}
+
+func roleError(err error) error {
+	if authErr := authorizationError(err); authErr != nil {
+		return authErr
+	}
+	switch {
+	case errors.Is(err, usecase.ErrRoleNotFound):
+		return NewNotFoundError(MsgRoleNotFound)
+	case errors.Is(err, usecase.ErrRoleExists):
+		return NewAlreadyExistsError(MsgRoleExists)
+	case errors.Is(err, usecase.ErrUnknownPermission):
+		return NewValidationError(MsgUnknownPermission)
+	case errors.Is(err, usecase.ErrRoleInUse):
+		return NewFailedPreconditionError(MsgRoleInUse)
+	case errors.Is(err, usecase.ErrBuiltInRole):
+		return NewFailedPreconditionError(MsgBuiltInRole)
+	}
+	return NewInternalError(MsgRoleOperationFailed)
+}
+
+func toRoleData(role *entity.Role) *userpb.RoleData {
+	return &userpb.RoleData{
+		Id:          int32(role.ID),
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.PermissionNames(),
+		CreatedAt:   role.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:   role.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/usecase"
+	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
+	"gorm.io/gorm"
+)
+
+func (h *UserHandler) ListSessions(ctx context.Context, req *userpb.ListSessionsRequest) (*userpb.ListSessionsResponse, error) {
+	sessions, err := h.SessionUseCase.ListSessions(ctx)
+	if err != nil {
+		return nil, sessionError(err)
+	}
+	return listSessionsResponse(ctx, sessions), nil
+}
+
+func (h *UserHandler) RevokeSession(ctx context.Context, req *userpb.RevokeSessionRequest) (*userpb.RevokeSessionResponse, error) {
+	if req.SessionId <= 0 {
+		return nil, NewValidationError("Session ID must be positive")
+	}
+
+	if err := h.SessionUseCase.RevokeSession(ctx, uint(req.SessionId)); err != nil {
+		return nil, sessionError(err)
+	}
+
+	return &userpb.RevokeSessionResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgSessionRevoked,
+	}, nil
+}
+
+func (h *UserHandler) ListUserSessions(ctx context.Context, req *userpb.ListUserSessionsRequest) (*userpb.ListSessionsResponse, error) {
+	if req.UserId <= 0 {
+		return nil, NewValidationError("User ID must be positive")
+	}
+
+	sessions, err := h.SessionUseCase.ListUserSessions(ctx, uint(req.UserId))
+	if err != nil {
+		return nil, sessionError(err)
+	}
+	return listSessionsResponse(ctx, sessions), nil
+}
+
+func (h *UserHandler) RevokeUserSession(ctx context.Context, req *userpb.RevokeSessionRequest) (*userpb.RevokeSessionResponse, error) {
+	if req.SessionId <= 0 {
+		return nil, NewValidationError("Session ID must be positive")
+	}
+
+	if err := h.SessionUseCase.RevokeUserSession(ctx, uint(req.SessionId)); err != nil {
+		return nil, sessionError(err)
+	}
+
+	return &userpb.RevokeSessionResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgSessionRevoked,
+	}, nil
+}
+
+func sessionError(err error) error {
+	if authErr := authorizationError(err); authErr != nil {
+		return authErr
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return NewNotFoundError(MsgSessionNotFound)
+	}
+	return NewInternalError(MsgSessionOperationFailed)
+}
+
+func listSessionsResponse(ctx context.Context, sessions []*entity.Session) *userpb.ListSessionsResponse {
+	var current string
+	if principal, ok := usecase.PrincipalFromContext(ctx); ok {
+		current = principal.SessionID
+	}
+
+	var data []*userpb.SessionData
+	for _, session := range sessions {
+		data = append(data, &userpb.SessionData{
+			Id:         int32(session.ID),
+			UserId:     int32(session.UserID),
+			UserAgent:  session.UserAgent,
+			IpAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt.Format(time.RFC3339),
+			LastUsedAt: session.LastUsedAt.Format(time.RFC3339),
+			Current:    current != "" && session.FamilyID == current,
+			ClientId:   session.ClientID,
+		})
+	}
+
+	return &userpb.ListSessionsResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgSessionsRetrieved,
+		Data:    data,
+	}
+}
@@ -2,22 +2,46 @@ package grpc
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"net"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
 	"github.com/aungmyozaw92/go-grpc-starter/internal/usecase"
 	"github.com/aungmyozaw92/go-grpc-starter/proto/userpb"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"gorm.io/gorm"
 )
 
 type UserHandler struct {
 	userpb.UnimplementedUserServiceServer
-	UserUseCase *usecase.UserUseCase
+	UserUseCase              *usecase.UserUseCase
+	RoleUseCase              *usecase.RoleUseCase
+	PasswordResetUseCase     *usecase.PasswordResetUseCase
+	EmailVerificationUseCase *usecase.EmailVerificationUseCase
+	MFAUseCase               *usecase.MFAUseCase
+	APIKeyUseCase            *usecase.APIKeyUseCase
+	SessionUseCase           *usecase.SessionUseCase
+	OAuthUseCase             *usecase.OAuthUseCase
+	OIDCLoginUseCase         *usecase.OIDCLoginUseCase
 }
 
-func NewUserHandler(userUseCase *usecase.UserUseCase) *UserHandler {
-	return &UserHandler{UserUseCase: userUseCase}
+func NewUserHandler(userUseCase *usecase.UserUseCase, roleUseCase *usecase.RoleUseCase, passwordResetUseCase *usecase.PasswordResetUseCase, emailVerificationUseCase *usecase.EmailVerificationUseCase, mfaUseCase *usecase.MFAUseCase, apiKeyUseCase *usecase.APIKeyUseCase, sessionUseCase *usecase.SessionUseCase, oauthUseCase *usecase.OAuthUseCase, oidcLoginUseCase *usecase.OIDCLoginUseCase) *UserHandler {
+	return &UserHandler{
+		UserUseCase:              userUseCase,
+		RoleUseCase:              roleUseCase,
+		PasswordResetUseCase:     passwordResetUseCase,
+		EmailVerificationUseCase: emailVerificationUseCase,
+		MFAUseCase:               mfaUseCase,
+		APIKeyUseCase:            apiKeyUseCase,
+		SessionUseCase:           sessionUseCase,
+		OAuthUseCase:             oauthUseCase,
+		OIDCLoginUseCase:         oidcLoginUseCase,
+	}
 }
 
 // validateRegisterRequest validates the registration request
@@ -51,16 +75,7 @@ func (h *UserHandler) validateRegisterRequest(req *userpb.RegisterRequest) error
 		return NewValidationError(MsgInvalidUsername)
 	}
 
-	// Validate password strength (minimum 6 characters)
-	if len(req.Password) < 6 {
-		return NewValidationError(MsgPasswordTooShort)
-	}
-
-	// Validate role_id (should be positive)
-	if req.RoleId <= 0 {
-		return NewValidationError(MsgInvalidRoleID)
-	}
-
+	// Password strength is up to the password policy in the usecase
 	return nil
 }
 
@@ -78,64 +93,252 @@ func (h *UserHandler) Register(ctx context.Context, req *userpb.RegisterRequest)
 		Mobile:   req.Mobile,
 		ImageURL: req.ImageUrl,
 		Password: req.Password,
-		IsActive: &req.IsActive,
-		RoleID:   int(req.RoleId),
 	}
 
-	token, err := h.UserUseCase.Register(user)
+	tokens, err := h.UserUseCase.Register(ctx, user, clientInfo(ctx))
 	if err != nil {
-		if err.Error() == "username already exists" {
+		if busyErr := overloadError(err); busyErr != nil {
+			return nil, busyErr
+		}
+		if errors.Is(err, usecase.ErrUsernameExists) {
 			return nil, NewAlreadyExistsError(MsgUsernameExists)
 		}
-		if err.Error() == "email already exists" {
+		if errors.Is(err, usecase.ErrEmailExists) {
 			return nil, NewAlreadyExistsError(MsgEmailExists)
 		}
+		if policyErr, ok := usecase.IsPasswordPolicyViolation(err); ok {
+			return nil, NewPasswordPolicyError("password", policyErr.Violations)
+		}
+		if errors.Is(err, infrastructure.ErrPasswordTooLong) {
+			return nil, NewValidationError(MsgPasswordTooLong)
+		}
 		return nil, NewInternalError(MsgUserRegistrationFailed)
 	}
 
-	return &userpb.AuthResponse{
-		Success: true,
-		Code:    string(CodeSuccess),
-		Message: MsgUserRegistered,
-		Token:   token,
-	}, nil
+	// Sign-in waits for email verification
+	if tokens == nil {
+		return &userpb.AuthResponse{
+			Success: true,
+			Code:    string(CodeSuccess),
+			Message: MsgUserRegisteredVerifyEmail,
+		}, nil
+	}
+	return newAuthResponse(MsgUserRegistered, tokens), nil
 }
 
 func (h *UserHandler) Login(ctx context.Context, req *userpb.LoginRequest) (*userpb.AuthResponse, error) {
-	// Debug logging to see what we receive
-	fmt.Printf("DEBUG Login - Username: '%s', Password: '%s'\n", req.Username, req.Password)
+	// Older clients send the identifier as username
+	identifier := req.Identifier
+	if identifier == "" {
+		identifier = req.Username
+	}
 
 	// Validate login request
-	if strings.TrimSpace(req.Username) == "" {
-		return nil, NewValidationError(MsgUsernameRequired)
+	if strings.TrimSpace(identifier) == "" {
+		return nil, NewValidationError(MsgIdentifierRequired)
 	}
 
 	if strings.TrimSpace(req.Password) == "" {
 		return nil, NewValidationError(MsgPasswordRequired)
 	}
 
-	token, err := h.UserUseCase.Login(req.Username, req.Password)
+	tokens, challenge, err := h.UserUseCase.Login(ctx, identifier, req.Password, clientInfo(ctx))
 	if err != nil {
-		return nil, NewAuthenticationError(MsgInvalidCredentials)
+		if throttled, ok := usecase.IsLoginThrottled(err); ok {
+			return nil, NewLoginThrottledError(throttled.RetryAfter, throttled.Locked)
+		}
+		if busyErr := overloadError(err); busyErr != nil {
+			return nil, busyErr
+		}
+		if errors.Is(err, usecase.ErrInvalidCredentials) {
+			return nil, NewAuthenticationError(MsgInvalidCredentials)
+		}
+		if inactive, ok := usecase.IsAccountInactive(err); ok {
+			return nil, NewAccountInactiveError(inactive)
+		}
+		if errors.Is(err, usecase.ErrEmailNotVerified) {
+			return nil, NewFailedPreconditionError(MsgEmailNotVerified)
+		}
+		return nil, NewInternalError(MsgUserLoginFailed)
 	}
 
-	return &userpb.AuthResponse{
+	if challenge != nil {
+		return &userpb.AuthResponse{
+			Success:           true,
+			Code:              string(CodeSuccess),
+			Message:           MsgMFARequired,
+			MfaRequired:       true,
+			MfaToken:          challenge.Token,
+			MfaTokenExpiresAt: challenge.ExpiresAt.Format(time.RFC3339),
+		}, nil
+	}
+	return newAuthResponse(MsgUserLoggedIn, tokens), nil
+}
+
+func (h *UserHandler) UnlockUser(ctx context.Context, req *userpb.UnlockUserRequest) (*userpb.UnlockUserResponse, error) {
+	if req.UserId <= 0 {
+		return nil, NewValidationError("User ID must be positive")
+	}
+
+	ip := strings.TrimSpace(req.Ip)
+	if ip != "" && net.ParseIP(ip) == nil {
+		return nil, NewValidationError(MsgInvalidIP)
+	}
+
+	if err := h.UserUseCase.UnlockUser(ctx, int(req.UserId), ip); err != nil {
+		if authErr := authorizationError(err); authErr != nil {
+			return nil, authErr
+		}
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, NewNotFoundError(MsgUserNotFound)
+		}
+		return nil, NewInternalError(MsgUserUnlockFailed)
+	}
+
+	return &userpb.UnlockUserResponse{
 		Success: true,
 		Code:    string(CodeSuccess),
-		Message: MsgUserLoggedIn,
-		Token:   token,
+		Message: MsgUserUnlocked,
 	}, nil
 }
 
-func (h *UserHandler) GetProfile(ctx context.Context, req *userpb.ProfileRequest) (*userpb.ProfileResponse, error) {
-	// Validate profile request
+// clientInfo describes the caller from the gRPC transport and metadata.
+func clientInfo(ctx context.Context) usecase.ClientInfo {
+	client := usecase.ClientInfo{IP: clientIP(ctx)}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("user-agent"); len(values) > 0 {
+			client.UserAgent = values[0]
+		}
+	}
+	return client
+}
+
+// clientIP returns the caller's IP address as seen by the gRPC transport.
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+func (h *UserHandler) RefreshToken(ctx context.Context, req *userpb.RefreshTokenRequest) (*userpb.AuthResponse, error) {
+	if strings.TrimSpace(req.RefreshToken) == "" {
+		return nil, NewValidationError(MsgRefreshTokenRequired)
+	}
+
+	tokens, err := h.UserUseCase.RefreshToken(ctx, req.RefreshToken)
+	if err != nil {
+		if errors.Is(err, usecase.ErrRefreshTokenReused) {
+			return nil, NewAuthenticationError(MsgRefreshTokenReused)
+		}
+		if errors.Is(err, usecase.ErrInvalidRefreshToken) {
+			return nil, NewAuthenticationError(MsgInvalidRefreshToken)
+		}
+		if inactive, ok := usecase.IsAccountInactive(err); ok {
+			return nil, NewAccountInactiveError(inactive)
+		}
+		if errors.Is(err, usecase.ErrEmailNotVerified) {
+			return nil, NewFailedPreconditionError(MsgEmailNotVerified)
+		}
+		return nil, NewInternalError(MsgTokenRefreshFailed)
+	}
+
+	return newAuthResponse(MsgTokenRefreshed, tokens), nil
+}
+
+func (h *UserHandler) Logout(ctx context.Context, req *userpb.LogoutRequest) (*userpb.LogoutResponse, error) {
+	if err := h.UserUseCase.Logout(ctx, req.RefreshToken); err != nil {
+		if authErr := authorizationError(err); authErr != nil {
+			return nil, authErr
+		}
+		return nil, NewInternalError(MsgLogoutFailed)
+	}
+
+	return &userpb.LogoutResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgUserLoggedOut,
+	}, nil
+}
+
+func (h *UserHandler) RevokeAllSessions(ctx context.Context, req *userpb.RevokeAllSessionsRequest) (*userpb.LogoutResponse, error) {
+	if err := h.UserUseCase.RevokeAllSessions(ctx); err != nil {
+		if authErr := authorizationError(err); authErr != nil {
+			return nil, authErr
+		}
+		return nil, NewInternalError(MsgLogoutFailed)
+	}
+
+	return &userpb.LogoutResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgSessionsRevoked,
+	}, nil
+}
+
+func (h *UserHandler) IntrospectToken(ctx context.Context, req *userpb.IntrospectTokenRequest) (*userpb.IntrospectTokenResponse, error) {
 	if strings.TrimSpace(req.Token) == "" {
 		return nil, NewValidationError(MsgTokenRequired)
 	}
 
-	user, err := h.UserUseCase.GetProfile(req.Token)
+	result, err := h.UserUseCase.IntrospectToken(ctx, req.Token)
 	if err != nil {
-		return nil, NewAuthenticationError(MsgInvalidToken)
+		if authErr := authorizationError(err); authErr != nil {
+			return nil, authErr
+		}
+		return nil, NewInternalError(MsgTokenIntrospectionFailed)
+	}
+
+	data := &userpb.TokenIntrospectionData{Active: result.Active}
+	if result.Active {
+		data.Sub = result.Subject
+		data.UserId = int32(result.UserID)
+		data.Username = result.Username
+		data.Roles = result.Roles
+		data.Jti = result.JTI
+		data.TokenType = "Bearer"
+		data.Iat = result.IssuedAt.Unix()
+		data.Exp = result.ExpiresAt.Unix()
+	}
+
+	return &userpb.IntrospectTokenResponse{
+		Success: true,
+		Code:    string(CodeSuccess),
+		Message: MsgTokenIntrospected,
+		Data:    data,
+	}, nil
+}
+
+func isTokenError(err error) bool {
+	return errors.Is(err, infrastructure.ErrInvalidToken) || errors.Is(err, infrastructure.ErrRevokedToken)
+}
+
+func newAuthResponse(message string, tokens *usecase.AuthTokens) *userpb.AuthResponse {
+	return &userpb.AuthResponse{
+		Success:                true,
+		Code:                   string(CodeSuccess),
+		Message:                message,
+		Token:                  tokens.AccessToken,
+		AccessToken:            tokens.AccessToken,
+		RefreshToken:           tokens.RefreshToken,
+		AccessTokenExpiresAt:   tokens.AccessTokenExpiresAt.Format(time.RFC3339),
+		RefreshTokenExpiresAt:  tokens.RefreshTokenExpiresAt.Format(time.RFC3339),
+		PasswordChangeRequired: tokens.PasswordChangeRequired,
+	}
+}
+
+func (h *UserHandler) GetProfile(ctx context.Context, req *userpb.ProfileRequest) (*userpb.ProfileResponse, error) {
+	user, err := h.UserUseCase.GetProfile(ctx)
+	if err != nil {
+		if authErr := authorizationError(err); authErr != nil {
+			return nil, authErr
+		}
+		return nil, NewNotFoundError(MsgUserNotFound)
 	}
 
 	return &userpb.ProfileResponse{
@@ -143,21 +346,49 @@ func (h *UserHandler) GetProfile(ctx context.Context, req *userpb.ProfileRequest
 		Code:    string(CodeSuccess),
 		Message: MsgProfileRetrieved,
 		Data: &userpb.ProfileData{
-			Id:        int32(user.ID),
-			Username:  user.Username,
-			Name:      user.Name,
-			Email:     deref(user.Email),
-			Phone:     user.Phone,
-			Mobile:    user.Mobile,
-			IsActive:  derefBool(user.IsActive),
-			RoleId:    int32(user.RoleID),
-			ImageUrl:  user.ImageURL,
-			CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-			UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			Id:                     int32(user.ID),
+			Username:               user.Username,
+			Name:                   user.Name,
+			Email:                  deref(user.Email),
+			Phone:                  user.Phone,
+			Mobile:                 user.Mobile,
+			IsActive:               user.EffectiveStatus(time.Now()) == entity.UserStatusActive,
+			RoleId:                 int32(user.RoleID),
+			ImageUrl:               user.ImageURL,
+			CreatedAt:              user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			UpdatedAt:              user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			EmailVerifiedAt:        formatTime(user.EmailVerifiedAt),
+			PasswordChangedAt:      formatTime(user.PasswordChangedAt),
+			PasswordChangeRequired: user.PasswordChangeRequired,
+			Status:                 string(user.Status),
+			StatusReason:           user.StatusReason,
+			StatusExpiresAt:        formatTime(user.StatusExpiresAt),
 		},
 	}, nil
 }
 
+func toUserData(user *entity.User) *userpb.UserData {
+	return &userpb.UserData{
+		Id:                     int32(user.ID),
+		Username:               user.Username,
+		Name:                   user.Name,
+		Email:                  deref(user.Email),
+		Phone:                  user.Phone,
+		Mobile:                 user.Mobile,
+		IsActive:               user.EffectiveStatus(time.Now()) == entity.UserStatusActive,
+		RoleId:                 int32(user.RoleID),
+		ImageUrl:               user.ImageURL,
+		CreatedAt:              user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:              user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		EmailVerifiedAt:        formatTime(user.EmailVerifiedAt),
+		PasswordChangedAt:      formatTime(user.PasswordChangedAt),
+		PasswordChangeRequired: user.PasswordChangeRequired,
+		Status:                 string(user.Status),
+		StatusReason:           user.StatusReason,
+		StatusExpiresAt:        formatTime(user.StatusExpiresAt),
+	}
+}
+
 func deref(s *string) string {
 	if s != nil {
 		return *s
@@ -165,19 +396,14 @@ func deref(s *string) string {
 	return ""
 }
 
-func derefBool(b *bool) bool {
-	if b != nil {
-		return *b
+func formatTime(t *time.Time) string {
+	if t != nil {
+		return t.Format(time.RFC3339)
 	}
-	return false
+	return ""
 }
 
 func (h *UserHandler) GetUserList(ctx context.Context, req *userpb.UserListRequest) (*userpb.UserListResponse, error) {
-	// Validate token
-	if strings.TrimSpace(req.Token) == "" {
-		return nil, NewValidationError(MsgTokenRequired)
-	}
-
 	// Set default pagination values
 	page := int(req.Page)
 	limit := int(req.Limit)
@@ -191,28 +417,18 @@ func (h *UserHandler) GetUserList(ctx context.Context, req *userpb.UserListReque
 	}
 
 	// Get user list from usecase
-	result, err := h.UserUseCase.GetUserList(req.Token, page, limit, search)
+	result, err := h.UserUseCase.GetUserList(ctx, page, limit, search)
 	if err != nil {
-		return nil, NewAuthenticationError(MsgInvalidToken)
+		if authErr := authorizationError(err); authErr != nil {
+			return nil, authErr
+		}
+		return nil, NewInternalError(MsgDatabaseError)
 	}
 
 	// Convert users to protobuf format
 	var pbUsers []*userpb.UserData
 	for _, user := range result.Users {
-		pbUser := &userpb.UserData{
-			Id:        int32(user.ID),
-			Username:  user.Username,
-			Name:      user.Name,
-			Email:     deref(user.Email),
-			Phone:     user.Phone,
-			Mobile:    user.Mobile,
-			IsActive:  derefBool(user.IsActive),
-			RoleId:    int32(user.RoleID),
-			ImageUrl:  user.ImageURL,
-			CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-			UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		}
-		pbUsers = append(pbUsers, pbUser)
+		pbUsers = append(pbUsers, toUserData(user))
 	}
 
 	// Create pagination metadata
@@ -237,36 +453,22 @@ func (h *UserHandler) GetUserList(ctx context.Context, req *userpb.UserListReque
 }
 
 func (h *UserHandler) GetUser(ctx context.Context, req *userpb.GetUserRequest) (*userpb.GetUserResponse, error) {
-	// Validate token
-	if strings.TrimSpace(req.Token) == "" {
-		return nil, NewValidationError(MsgTokenRequired)
-	}
-
 	// Validate user ID
 	if req.UserId <= 0 {
 		return nil, NewValidationError("User ID must be positive")
 	}
 
 	// Get user from usecase
-	user, err := h.UserUseCase.GetUser(req.Token, int(req.UserId))
+	user, err := h.UserUseCase.GetUser(ctx, int(req.UserId))
 	if err != nil {
+		if authErr := authorizationError(err); authErr != nil {
+			return nil, authErr
+		}
 		return nil, NewNotFoundError(MsgUserNotFound)
 	}
 
 	// Convert to protobuf format
-	userData := &userpb.UserData{
-		Id:        int32(user.ID),
-		Username:  user.Username,
-		Name:      user.Name,
-		Email:     deref(user.Email),
-		Phone:     user.Phone,
-		Mobile:    user.Mobile,
-		IsActive:  derefBool(user.IsActive),
-		RoleId:    int32(user.RoleID),
-		ImageUrl:  user.ImageURL,
-		CreatedAt: user.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: user.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	}
+	userData := toUserData(user)
 
 	return &userpb.GetUserResponse{
 		Success: true,
@@ -277,11 +479,6 @@ func (h *UserHandler) GetUser(ctx context.Context, req *userpb.GetUserRequest) (
 }
 
 func (h *UserHandler) CreateUser(ctx context.Context, req *userpb.CreateUserRequest) (*userpb.CreateUserResponse, error) {
-	// Validate token
-	if strings.TrimSpace(req.Token) == "" {
-		return nil, NewValidationError(MsgTokenRequired)
-	}
-
 	// Validate required fields
 	if strings.TrimSpace(req.Username) == "" {
 		return nil, NewValidationError(MsgUsernameRequired)
@@ -305,36 +502,38 @@ func (h *UserHandler) CreateUser(ctx context.Context, req *userpb.CreateUserRequ
 		Mobile:   req.Mobile,
 		ImageURL: req.ImageUrl,
 		Password: req.Password,
-		IsActive: &req.IsActive,
 		RoleID:   int(req.RoleId),
 	}
 
 	// Create user via usecase
-	createdUser, err := h.UserUseCase.CreateUser(req.Token, user)
+	createdUser, err := h.UserUseCase.CreateUser(ctx, user)
 	if err != nil {
-		if err.Error() == "username already exists" {
+		if authErr := authorizationError(err); authErr != nil {
+			return nil, authErr
+		}
+		if busyErr := overloadError(err); busyErr != nil {
+			return nil, busyErr
+		}
+		if errors.Is(err, usecase.ErrRoleNotFound) {
+			return nil, NewNotFoundError(MsgRoleNotFound)
+		}
+		if errors.Is(err, usecase.ErrUsernameExists) {
 			return nil, NewAlreadyExistsError(MsgUsernameExists)
 		}
-		if err.Error() == "email already exists" {
+		if errors.Is(err, usecase.ErrEmailExists) {
 			return nil, NewAlreadyExistsError(MsgEmailExists)
 		}
+		if policyErr, ok := usecase.IsPasswordPolicyViolation(err); ok {
+			return nil, NewPasswordPolicyError("password", policyErr.Violations)
+		}
+		if errors.Is(err, infrastructure.ErrPasswordTooLong) {
+			return nil, NewValidationError(MsgPasswordTooLong)
+		}
 		return nil, NewInternalError(MsgUserCreationFailed)
 	}
 
 	// Convert to protobuf format
-	userData := &userpb.UserData{
-		Id:        int32(createdUser.ID),
-		Username:  createdUser.Username,
-		Name:      createdUser.Name,
-		Email:     deref(createdUser.Email),
-		Phone:     createdUser.Phone,
-		Mobile:    createdUser.Mobile,
-		IsActive:  derefBool(createdUser.IsActive),
-		RoleId:    int32(createdUser.RoleID),
-		ImageUrl:  createdUser.ImageURL,
-		CreatedAt: createdUser.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: createdUser.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	}
+	userData := toUserData(createdUser)
 
 	return &userpb.CreateUserResponse{
 		Success: true,
@@ -345,11 +544,6 @@ func (h *UserHandler) CreateUser(ctx context.Context, req *userpb.CreateUserRequ
 }
 
 func (h *UserHandler) UpdateUser(ctx context.Context, req *userpb.UpdateUserRequest) (*userpb.UpdateUserResponse, error) {
-	// Validate token
-	if strings.TrimSpace(req.Token) == "" {
-		return nil, NewValidationError(MsgTokenRequired)
-	}
-
 	// Validate user ID
 	if req.UserId <= 0 {
 		return nil, NewValidationError("User ID must be positive")
@@ -374,36 +568,29 @@ func (h *UserHandler) UpdateUser(ctx context.Context, req *userpb.UpdateUserRequ
 		Phone:    req.Phone,
 		Mobile:   req.Mobile,
 		ImageURL: req.ImageUrl,
-		IsActive: &req.IsActive,
 		RoleID:   int(req.RoleId),
 	}
 
 	// Update user via usecase
-	updatedUser, err := h.UserUseCase.UpdateUser(req.Token, int(req.UserId), updateData)
+	updatedUser, err := h.UserUseCase.UpdateUser(ctx, int(req.UserId), updateData)
 	if err != nil {
-		if err.Error() == "username already exists" {
+		if authErr := authorizationError(err); authErr != nil {
+			return nil, authErr
+		}
+		if errors.Is(err, usecase.ErrRoleNotFound) {
+			return nil, NewNotFoundError(MsgRoleNotFound)
+		}
+		if errors.Is(err, usecase.ErrUsernameExists) {
 			return nil, NewAlreadyExistsError(MsgUsernameExists)
 		}
-		if err.Error() == "email already exists" {
+		if errors.Is(err, usecase.ErrEmailExists) {
 			return nil, NewAlreadyExistsError(MsgEmailExists)
 		}
 		return nil, NewInternalError(MsgUserUpdateFailed)
 	}
 
 	// Convert to protobuf format
-	userData := &userpb.UserData{
-		Id:        int32(updatedUser.ID),
-		Username:  updatedUser.Username,
-		Name:      updatedUser.Name,
-		Email:     deref(updatedUser.Email),
-		Phone:     updatedUser.Phone,
-		Mobile:    updatedUser.Mobile,
-		IsActive:  derefBool(updatedUser.IsActive),
-		RoleId:    int32(updatedUser.RoleID),
-		ImageUrl:  updatedUser.ImageURL,
-		CreatedAt: updatedUser.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt: updatedUser.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	}
+	userData := toUserData(updatedUser)
 
 	return &userpb.UpdateUserResponse{
 		Success: true,
@@ -414,19 +601,17 @@ func (h *UserHandler) UpdateUser(ctx context.Context, req *userpb.UpdateUserRequ
 }
 
 func (h *UserHandler) DeleteUser(ctx context.Context, req *userpb.DeleteUserRequest) (*userpb.DeleteUserResponse, error) {
-	// Validate token
-	if strings.TrimSpace(req.Token) == "" {
-		return nil, NewValidationError(MsgTokenRequired)
-	}
-
 	// Validate user ID
 	if req.UserId <= 0 {
 		return nil, NewValidationError("User ID must be positive")
 	}
 
 	// Delete user via usecase
-	err := h.UserUseCase.DeleteUser(req.Token, int(req.UserId))
+	err := h.UserUseCase.DeleteUser(ctx, int(req.UserId))
 	if err != nil {
+		if authErr := authorizationError(err); authErr != nil {
+			return nil, authErr
+		}
 		return nil, NewInternalError(MsgUserDeletionFailed)
 	}
 
@@ -0,0 +1,32 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
+)
+
+const JWKSPath = "/.well-known/jwks.json"
+
+type JWKSHandler struct {
+	keys *infrastructure.JWTKeySet
+}
+
+func NewJWKSHandler(keys *infrastructure.JWTKeySet) *JWKSHandler {
+	return &JWKSHandler{keys: keys}
+}
+
+func (h *JWKSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	// Let verifiers cache keys briefly; rotations keep the old key published
+	// for longer than this.
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	json.NewEncoder(w).Encode(h.keys.JWKS())
+}
@@ -0,0 +1,302 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/usecase"
+)
+
+// Endpoints of the OAuth 2.0 and OpenID Connect provider.
+const (
+	DiscoveryPath = "/.well-known/openid-configuration"
+	AuthorizePath = "/oauth2/authorize"
+	TokenPath     = "/oauth2/token"
+	UserInfoPath  = "/oauth2/userinfo"
+)
+
+// Authenticator turns a bearer credential into a principal.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*usecase.Principal, error)
+}
+
+// OAuthHandler serves the provider's endpoints. The authorization endpoint
+// has no sign-in page of its own: the sign-in UI calls Login and sends the
+// user's access token as a bearer token, and gets the redirect to the
+// client back.
+type OAuthHandler struct {
+	oauth         *usecase.OAuthUseCase
+	authenticator Authenticator
+	keys          *infrastructure.JWTKeySet
+}
+
+func NewOAuthHandler(oauth *usecase.OAuthUseCase, authenticator Authenticator, keys *infrastructure.JWTKeySet) *OAuthHandler {
+	return &OAuthHandler{oauth: oauth, authenticator: authenticator, keys: keys}
+}
+
+func (h *OAuthHandler) register(mux *http.ServeMux) {
+	mux.HandleFunc(DiscoveryPath, h.discovery)
+	mux.HandleFunc(AuthorizePath, h.authorize)
+	mux.HandleFunc(TokenPath, h.token)
+	mux.HandleFunc(UserInfoPath, h.userInfo)
+}
+
+type discoveryDocument struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+func (h *OAuthHandler) discovery(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet, http.MethodHead) {
+		return
+	}
+	issuer := h.oauth.Issuer()
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeJSON(w, http.StatusOK, discoveryDocument{
+		Issuer:                 issuer,
+		AuthorizationEndpoint:  issuer + AuthorizePath,
+		TokenEndpoint:          issuer + TokenPath,
+		UserInfoEndpoint:       issuer + UserInfoPath,
+		JWKSURI:                issuer + JWKSPath,
+		ResponseTypesSupported: []string{"code"},
+		GrantTypesSupported: []string{
+			entity.OAuthGrantAuthorizationCode,
+			entity.OAuthGrantRefreshToken,
+			entity.OAuthGrantClientCredentials,
+		},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{h.keys.Signing().Method.Alg()},
+		ScopesSupported:                   []string{entity.OAuthScopeOpenID, entity.OAuthScopeProfile, entity.OAuthScopeEmail},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+		ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "nonce", "azp", "name", "preferred_username", "email", "email_verified"},
+	})
+}
+
+func (h *OAuthHandler) authorize(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet, http.MethodPost) {
+		return
+	}
+
+	ctx := r.Context()
+	if token, ok := bearerToken(r); ok {
+		principal, err := h.authenticator.Authenticate(r.Context(), token)
+		if err != nil {
+			writeLoginRequired(w)
+			return
+		}
+		ctx = usecase.WithPrincipal(ctx, principal)
+	}
+
+	location, err := h.oauth.Authorize(ctx, usecase.AuthorizationRequest{
+		ResponseType:        r.FormValue("response_type"),
+		ClientID:            r.FormValue("client_id"),
+		RedirectURI:         r.FormValue("redirect_uri"),
+		Scope:               r.FormValue("scope"),
+		State:               r.FormValue("state"),
+		Nonce:               r.FormValue("nonce"),
+		CodeChallenge:       r.FormValue("code_challenge"),
+		CodeChallengeMethod: r.FormValue("code_challenge_method"),
+	}, clientInfo(r))
+	if err != nil {
+		if oauthErr, ok := usecase.IsOAuthError(err); ok {
+			if location := oauthErr.Location(); location != "" {
+				http.Redirect(w, r, location, http.StatusFound)
+				return
+			}
+			writeOAuthError(w, http.StatusBadRequest, oauthErr)
+			return
+		}
+		switch {
+		case errors.Is(err, usecase.ErrUnauthenticated):
+			writeLoginRequired(w)
+		case errors.Is(err, usecase.ErrPermissionDenied):
+			writeOAuthError(w, http.StatusForbidden, &usecase.OAuthError{Code: "access_denied", Description: "API keys cannot authorize clients"})
+		default:
+			writeServerError(w, err)
+		}
+		return
+	}
+	http.Redirect(w, r, location, http.StatusFound)
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	IDToken      string `json:"id_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+func (h *OAuthHandler) token(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodPost) {
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	if err := r.ParseForm(); err != nil {
+		writeOAuthError(w, http.StatusBadRequest, &usecase.OAuthError{Code: usecase.OAuthInvalidRequest, Description: "malformed request body"})
+		return
+	}
+
+	req := usecase.TokenRequest{
+		GrantType:    r.PostForm.Get("grant_type"),
+		ClientID:     r.PostForm.Get("client_id"),
+		ClientSecret: r.PostForm.Get("client_secret"),
+		Code:         r.PostForm.Get("code"),
+		RedirectURI:  r.PostForm.Get("redirect_uri"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+		RefreshToken: r.PostForm.Get("refresh_token"),
+		Scope:        r.PostForm.Get("scope"),
+	}
+	// RFC 6749 form-encodes the credentials before basic authentication
+	username, password, basic := r.BasicAuth()
+	if basic {
+		clientID, errID := url.QueryUnescape(username)
+		secret, errSecret := url.QueryUnescape(password)
+		if errID != nil || errSecret != nil {
+			w.Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+			writeOAuthError(w, http.StatusUnauthorized, &usecase.OAuthError{Code: usecase.OAuthInvalidClient, Description: "malformed client credentials"})
+			return
+		}
+		req.ClientID, req.ClientSecret = clientID, secret
+	}
+
+	tokens, err := h.oauth.Token(r.Context(), req)
+	if err != nil {
+		oauthErr, ok := usecase.IsOAuthError(err)
+		if !ok {
+			writeServerError(w, err)
+			return
+		}
+		status := http.StatusBadRequest
+		if oauthErr.Code == usecase.OAuthInvalidClient {
+			status = http.StatusUnauthorized
+			if basic {
+				w.Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+			}
+		}
+		writeOAuthError(w, status, oauthErr)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, tokenResponse{
+		AccessToken:  tokens.AccessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(time.Until(tokens.ExpiresAt).Round(time.Second).Seconds()),
+		RefreshToken: tokens.RefreshToken,
+		IDToken:      tokens.IDToken,
+		Scope:        tokens.Scope,
+	})
+}
+
+func (h *OAuthHandler) userInfo(w http.ResponseWriter, r *http.Request) {
+	if !allowMethods(w, r, http.MethodGet, http.MethodPost) {
+		return
+	}
+	token, ok := bearerToken(r)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer`)
+		writeOAuthError(w, http.StatusUnauthorized, &usecase.OAuthError{Code: usecase.OAuthInvalidRequest, Description: "missing bearer token"})
+		return
+	}
+
+	info, err := h.oauth.UserInfo(r.Context(), token)
+	if err != nil {
+		if oauthErr, ok := usecase.IsOAuthError(err); ok {
+			w.Header().Set("WWW-Authenticate", `Bearer error="`+oauthErr.Code+`"`)
+			writeOAuthError(w, http.StatusForbidden, oauthErr)
+			return
+		}
+		if errors.Is(err, infrastructure.ErrInvalidToken) || errors.Is(err, infrastructure.ErrRevokedToken) {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			writeOAuthError(w, http.StatusUnauthorized, &usecase.OAuthError{Code: "invalid_token", Description: "the access token is invalid"})
+			return
+		}
+		if _, inactive := usecase.IsAccountInactive(err); inactive {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			writeOAuthError(w, http.StatusUnauthorized, &usecase.OAuthError{Code: "invalid_token", Description: "the user's account is not active"})
+			return
+		}
+		writeServerError(w, err)
+		return
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, info)
+}
+
+type oauthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+func writeOAuthError(w http.ResponseWriter, status int, err *usecase.OAuthError) {
+	writeJSON(w, status, oauthErrorResponse{Error: err.Code, ErrorDescription: err.Description})
+}
+
+func writeLoginRequired(w http.ResponseWriter) {
+	w.Header().Set("WWW-Authenticate", `Bearer`)
+	writeOAuthError(w, http.StatusUnauthorized, &usecase.OAuthError{Code: "login_required", Description: "sign in and send the access token as a bearer token"})
+}
+
+func writeServerError(w http.ResponseWriter, err error) {
+	log.Printf("OAuth request failed: %v", err)
+	writeOAuthError(w, http.StatusInternalServerError, &usecase.OAuthError{Code: "server_error", Description: "internal error"})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+// allowMethods answers 405 unless the request uses one of methods.
+func allowMethods(w http.ResponseWriter, r *http.Request, methods ...string) bool {
+	for _, method := range methods {
+		if r.Method == method {
+			return true
+		}
+	}
+	w.Header().Set("Allow", strings.Join(methods, ", "))
+	http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+	return false
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// clientInfo describes the user's browser or app.
+func clientInfo(r *http.Request) usecase.ClientInfo {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		ip = host
+	}
+	return usecase.ClientInfo{IP: ip, UserAgent: r.UserAgent()}
+}
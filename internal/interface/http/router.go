@@ -0,0 +1,31 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MetricsPath serves Prometheus metrics.
+const MetricsPath = "/metrics"
+
+// NewRouter builds the HTTP endpoints served next to the gRPC server. oauth
+// is nil when the OAuth provider is off.
+func NewRouter(keys *infrastructure.JWTKeySet, oauth *OAuthHandler) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(JWKSPath, NewJWKSHandler(keys))
+	if oauth != nil {
+		oauth.register(mux)
+	}
+	return mux
+}
+
+// NewMetricsRouter builds the endpoints for monitoring. They tell anyone how
+// busy the server is, so they are served on an internal address of their
+// own rather than next to the public ones.
+func NewMetricsRouter() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(MetricsPath, promhttp.Handler())
+	return mux
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+)
+
+type APIKeyRepository interface {
+	Create(key *entity.APIKey) error
+	FindByID(id uint) (*entity.APIKey, error)
+	FindByPrefix(prefix string) (*entity.APIKey, error)
+	ListByUser(userID uint) ([]*entity.APIKey, error)
+	Revoke(id uint) error
+	RevokeAllForUser(userID uint) error
+	MarkUsed(id uint, usedAt time.Time) error
+}
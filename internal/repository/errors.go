@@ -0,0 +1,11 @@
+package repository
+
+import "errors"
+
+// ErrDuplicateUsername and ErrDuplicateEmail are returned by
+// UserRepository.Create and Update when another user, deleted ones
+// included, has the username or email.
+var (
+	ErrDuplicateUsername = errors.New("duplicate username")
+	ErrDuplicateEmail    = errors.New("duplicate email")
+)
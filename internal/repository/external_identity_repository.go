@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+)
+
+type ExternalIdentityRepository interface {
+	Create(identity *entity.ExternalIdentity) error
+	Find(provider, subject string) (*entity.ExternalIdentity, error)
+	MarkLogin(id uint, email string, at time.Time) error
+}
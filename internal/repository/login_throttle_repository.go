@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+)
+
+type LoginThrottleRepository interface {
+	FindByKeys(keys []string) ([]*entity.LoginThrottle, error)
+	// Reserve atomically counts an attempt against every key unless admit
+	// rejects the records as they stand. The records are locked for the
+	// check, counters whose last failure is older than window restart, and
+	// admit's error is returned without counting anything.
+	Reserve(keys []string, now time.Time, window time.Duration, admit func([]*entity.LoginThrottle) error) error
+	// Release takes back an attempt counted by Reserve.
+	Release(key string) error
+	// RecordFailure atomically increments the failure counter of key,
+	// restarting it when the previous failure is older than window, and
+	// returns the updated record.
+	RecordFailure(key string, now time.Time, window time.Duration) (*entity.LoginThrottle, error)
+	Lock(key string, until time.Time) error
+	Reset(key string) error
+	// DeleteExpired removes the records that no longer throttle anything:
+	// the last failure is older than window and any lock has ended.
+	DeleteExpired(now time.Time, window time.Duration) (int64, error)
+}
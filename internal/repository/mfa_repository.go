@@ -0,0 +1,19 @@
+package repository
+
+import "github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+
+type MFARepository interface {
+	FindTOTP(userID uint) (*entity.TOTPCredential, error)
+	// SaveTOTP replaces the user's credential, confirmed or not.
+	SaveTOTP(credential *entity.TOTPCredential) error
+	ConfirmTOTP(id uint, step int64) error
+	// AdvanceTOTPStep records step as used. It reports false when the step,
+	// or a later one, was already used.
+	AdvanceTOTPStep(id uint, step int64) (bool, error)
+	// DeleteTOTP removes the credential and all recovery codes.
+	DeleteTOTP(userID uint) error
+	ReplaceRecoveryCodes(userID uint, codeHashes []string) error
+	// UseRecoveryCode consumes an unused code. It reports false when the
+	// user has no such unused code.
+	UseRecoveryCode(userID uint, codeHash string) (bool, error)
+}
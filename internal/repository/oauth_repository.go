@@ -0,0 +1,16 @@
+package repository
+
+import "github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+
+type OAuthRepository interface {
+	CreateClient(client *entity.OAuthClient) error
+	FindClient(clientID string) (*entity.OAuthClient, error)
+	FindClientByID(id uint) (*entity.OAuthClient, error)
+	ListClients() ([]*entity.OAuthClient, error)
+	DeleteClient(id uint) error
+	CreateCode(code *entity.OAuthAuthorizationCode) error
+	FindCode(codeHash string) (*entity.OAuthAuthorizationCode, error)
+	// MarkCodeUsed flags the code as exchanged. It reports false when the
+	// code had already been used.
+	MarkCodeUsed(id uint) (bool, error)
+}
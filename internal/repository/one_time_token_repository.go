@@ -0,0 +1,13 @@
+package repository
+
+import "github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+
+type OneTimeTokenRepository interface {
+	Create(token *entity.OneTimeToken) error
+	FindByTokenHash(purpose, tokenHash string) (*entity.OneTimeToken, error)
+	// MarkUsed consumes the token. It reports false when the token had
+	// already been used.
+	MarkUsed(id uint) (bool, error)
+	// InvalidateForUser consumes every outstanding token of the purpose.
+	InvalidateForUser(userID uint, purpose string) error
+}
@@ -0,0 +1,11 @@
+package repository
+
+import "github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+
+type PasswordHistoryRepository interface {
+	// Recent returns up to limit entries of the user, newest first.
+	Recent(userID uint, limit int) ([]entity.PasswordHistory, error)
+	// Add stores entry and deletes all but the newest keep entries of the
+	// user.
+	Add(entry *entity.PasswordHistory, keep int) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import "github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+
+type RefreshTokenRepository interface {
+	Create(token *entity.RefreshToken) error
+	FindByTokenHash(tokenHash string) (*entity.RefreshToken, error)
+	// MarkUsed flags the token as exchanged. It reports false when the token
+	// had already been used, so concurrent refreshes cannot both succeed.
+	MarkUsed(id uint) (bool, error)
+	RevokeFamily(familyID string) error
+	RevokeAllForUser(userID uint) error
+}
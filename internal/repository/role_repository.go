@@ -0,0 +1,19 @@
+package repository
+
+import "github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+
+type RoleRepository interface {
+	Create(role *entity.Role) error
+	// Update saves the role and replaces its permission set.
+	Update(role *entity.Role) error
+	Delete(id uint) error
+	FindByID(id uint) (*entity.Role, error)
+	FindByName(name string) (*entity.Role, error)
+	List() ([]*entity.Role, error)
+	ExistsByName(name string) (bool, error)
+	ExistsByNameExcludeID(name string, excludeID uint) (bool, error)
+	ListPermissions() ([]*entity.Permission, error)
+	FindPermissionsByNames(names []string) ([]entity.Permission, error)
+	// EnsurePermissions creates any catalog permission that does not exist.
+	EnsurePermissions(permissions []entity.Permission) error
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+)
+
+// SessionRepository stores sessions. A session is active while its refresh
+// token family has a token that is neither revoked nor expired, so ending a
+// session means revoking its family.
+type SessionRepository interface {
+	Create(session *entity.Session) error
+	FindActiveByID(id uint) (*entity.Session, error)
+	FindActiveByFamilyID(familyID string) (*entity.Session, error)
+	ListActive(userID uint) ([]*entity.Session, error)
+	MarkUsed(familyID string, usedAt time.Time) error
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "time"
+
+type TokenRevocationRepository interface {
+	RevokeToken(jti string, userID uint, expiresAt time.Time) error
+	RevokeAllForUser(userID uint, issuedBefore, expiresAt time.Time) error
+	IsRevoked(jti string, userID uint, issuedAt time.Time) (bool, error)
+	DeleteExpired(now time.Time) (int64, error)
+}
@@ -1,22 +1,41 @@
 package repository
 
 import (
+	"context"
+	"time"
+
 	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
 	"gorm.io/gorm"
 )
 
 type UserRepository interface {
-	Create(user *entity.User) error
-	FindByUsername(username string) (*entity.User, error)
-	FindByEmail(email string) (*entity.User, error)
-	FindByID(id int) (*entity.User, error)
-	Update(user *entity.User) error
-	Delete(id int) error
-	GetUserList(page, limit int, search string) ([]*entity.User, int64, error)
-	ExistsByUsername(username string) (bool, error)
-	ExistsByEmail(email string) (bool, error)
-	ExistsByUsernameExcludeID(username string, excludeID uint) (bool, error)
-	ExistsByEmailExcludeID(email string, excludeID uint) (bool, error)
+	// Create fills in the normalized username and email of user. It and
+	// Update fail with ErrDuplicateUsername or ErrDuplicateEmail when those
+	// are taken.
+	Create(ctx context.Context, user *entity.User) error
+	// FindByUsername, FindByEmail and the Exists methods compare normalized
+	// forms, see entity.NormalizeUsername and entity.NormalizeEmail.
+	FindByUsername(ctx context.Context, username string) (*entity.User, error)
+	FindByEmail(ctx context.Context, email string) (*entity.User, error)
+	FindByID(ctx context.Context, id int) (*entity.User, error)
+	Update(ctx context.Context, user *entity.User) error
+	// UpdatePassword replaces the hash of an unchanged password, e.g. after
+	// a rehash. SetPassword records a new password.
+	UpdatePassword(ctx context.Context, id uint, passwordHash string) error
+	SetPassword(ctx context.Context, id uint, passwordHash string, changedAt time.Time, changeRequired bool) error
+	UpdateStatus(ctx context.Context, id uint, status entity.UserStatus, reason string, expiresAt *time.Time) error
+	// MarkEmailVerified records that the user verified email, if that is
+	// still their address, and activates a pending account. An earlier
+	// verification time is kept.
+	MarkEmailVerified(ctx context.Context, id uint, email string, verifiedAt time.Time) error
+	// Delete marks the user deleted and soft-deletes the record.
+	Delete(ctx context.Context, id int) error
+	GetUserList(ctx context.Context, page, limit int, search string) ([]*entity.User, int64, error)
+	CountByRole(ctx context.Context, roleID uint) (int64, error)
+	ExistsByUsername(ctx context.Context, username string) (bool, error)
+	ExistsByEmail(ctx context.Context, email string) (bool, error)
+	ExistsByUsernameExcludeID(ctx context.Context, username string, excludeID uint) (bool, error)
+	ExistsByEmailExcludeID(ctx context.Context, email string, excludeID uint) (bool, error)
 }
 
 type userRepository struct {
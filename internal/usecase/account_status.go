@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+)
+
+// AccountInactiveError is returned when a user whose account is not active
+// signs in or presents a token.
+type AccountInactiveError struct {
+	Status    entity.UserStatus
+	Reason    string
+	ExpiresAt *time.Time
+}
+
+func (e *AccountInactiveError) Error() string {
+	return fmt.Sprintf("account is %s", e.Status)
+}
+
+// IsAccountInactive unwraps an AccountInactiveError from err.
+func IsAccountInactive(err error) (*AccountInactiveError, bool) {
+	var inactive *AccountInactiveError
+	ok := errors.As(err, &inactive)
+	return inactive, ok
+}
+
+// checkAccountStatus returns an AccountInactiveError unless the user's
+// account is active at now.
+func checkAccountStatus(user *entity.User, now time.Time) error {
+	status := user.EffectiveStatus(now)
+	if status == entity.UserStatusActive {
+		return nil
+	}
+	return &AccountInactiveError{
+		Status:    status,
+		Reason:    user.StatusReason,
+		ExpiresAt: user.StatusExpiresAt,
+	}
+}
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// lastUsedResolution limits how often the last use of a busy API key or
+// session is written.
+const lastUsedResolution = time.Minute
+
+type APIKeyUseCase struct {
+	apiKeyRepo   repository.APIKeyRepository
+	userRepo     repository.UserRepository
+	roleRepo     repository.RoleRepository
+	verification *EmailVerificationUseCase
+}
+
+func NewAPIKeyUseCase(apiKeyRepo repository.APIKeyRepository, userRepo repository.UserRepository, roleRepo repository.RoleRepository, verification *EmailVerificationUseCase) *APIKeyUseCase {
+	return &APIKeyUseCase{
+		apiKeyRepo:   apiKeyRepo,
+		userRepo:     userRepo,
+		roleRepo:     roleRepo,
+		verification: verification,
+	}
+}
+
+// CreateAPIKey creates a key acting as userID, or as the caller when userID
+// is 0, limited to scopes. Every scope must be granted to the user's role.
+// Creating a key for another user with a role other than the default one
+// takes the permission to assign that role.
+// The returned plaintext key is not stored and cannot be shown again.
+func (u *APIKeyUseCase) CreateAPIKey(ctx context.Context, userID uint, name string, scopes []string, expiresAt *time.Time) (*entity.APIKey, string, error) {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	userID, err = u.authorizeOwner(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", ErrInvalidAPIKeyExpiry
+	}
+
+	user, err := u.userRepo.FindByID(ctx, int(userID))
+	if err != nil {
+		return nil, "", err
+	}
+	// A key acting as someone else carries their role, so for anything but
+	// the default role it is as good as assigning the role to oneself
+	if user.ID != principal.UserID && user.RoleID != entity.DefaultRoleID {
+		if err := checkRoleAssignment(u.roleRepo, principal, uint(user.RoleID)); err != nil {
+			return nil, "", err
+		}
+	}
+	permissions, err := resolvePermissions(u.roleRepo, scopes)
+	if err != nil {
+		return nil, "", err
+	}
+	granted, err := grantedPermissions(u.roleRepo, u.verification, user)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, permission := range permissions {
+		if !granted[permission.Name] {
+			return nil, "", ErrScopeNotGranted
+		}
+	}
+
+	plaintext, prefix, err := infrastructure.GenerateAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+	_, secret, _ := infrastructure.ParseAPIKey(plaintext)
+	key := &entity.APIKey{
+		UserID:     user.ID,
+		Name:       name,
+		Prefix:     prefix,
+		SecretHash: infrastructure.HashToken(secret),
+		Scopes:     permissions,
+		ExpiresAt:  expiresAt,
+	}
+	if err := u.apiKeyRepo.Create(key); err != nil {
+		return nil, "", err
+	}
+	return key, plaintext, nil
+}
+
+// ListAPIKeys returns the keys of userID, or of the caller when userID is 0,
+// including revoked and expired ones.
+func (u *APIKeyUseCase) ListAPIKeys(ctx context.Context, userID uint) ([]*entity.APIKey, error) {
+	userID, err := u.authorizeOwner(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return u.apiKeyRepo.ListByUser(userID)
+}
+
+// RevokeAPIKey disables a key for good. Revoking a revoked key is a no-op.
+func (u *APIKeyUseCase) RevokeAPIKey(ctx context.Context, id uint) error {
+	if _, err := requireSession(ctx); err != nil {
+		return err
+	}
+	key, err := u.apiKeyRepo.FindByID(id)
+	if err != nil {
+		return err
+	}
+	if _, err := u.authorizeOwner(ctx, key.UserID); err != nil {
+		return err
+	}
+	return u.apiKeyRepo.Revoke(key.ID)
+}
+
+// revokeAllForUser revokes every key of the user, for when the password
+// that stood behind them changes.
+func (u *APIKeyUseCase) revokeAllForUser(userID uint) error {
+	return u.apiKeyRepo.RevokeAllForUser(userID)
+}
+
+// Authenticate validates an API key and returns a principal acting as its
+// user, with the scopes of the key that the user's role still grants.
+func (u *APIKeyUseCase) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	prefix, secret, ok := infrastructure.ParseAPIKey(token)
+	if !ok {
+		return nil, infrastructure.ErrInvalidToken
+	}
+	key, err := u.apiKeyRepo.FindByPrefix(prefix)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, infrastructure.ErrInvalidToken
+		}
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(infrastructure.HashToken(secret)), []byte(key.SecretHash)) != 1 {
+		return nil, infrastructure.ErrInvalidToken
+	}
+
+	now := time.Now()
+	if key.RevokedAt != nil {
+		return nil, infrastructure.ErrRevokedToken
+	}
+	if key.ExpiresAt != nil && !now.Before(*key.ExpiresAt) {
+		return nil, infrastructure.ErrInvalidToken
+	}
+
+	user, err := u.userRepo.FindByID(ctx, int(key.UserID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, infrastructure.ErrInvalidToken
+		}
+		return nil, err
+	}
+	if err := checkAccountStatus(user, now); err != nil {
+		return nil, err
+	}
+
+	granted, err := grantedPermissions(u.roleRepo, u.verification, user)
+	if err != nil {
+		return nil, err
+	}
+	permissions := make(map[string]bool)
+	for _, name := range key.ScopeNames() {
+		if granted[name] {
+			permissions[name] = true
+		}
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= lastUsedResolution {
+		// Only bookkeeping, the request goes ahead either way
+		if err := u.apiKeyRepo.MarkUsed(key.ID, now); err != nil {
+			log.Printf("Failed to record use of API key %d: %v", key.ID, err)
+		}
+	}
+
+	principal := &Principal{
+		UserID:      user.ID,
+		RoleID:      uint(user.RoleID),
+		Permissions: permissions,
+		APIKeyID:    key.ID,
+	}
+	if key.ExpiresAt != nil {
+		principal.ExpiresAt = *key.ExpiresAt
+	}
+	return principal, nil
+}
+
+// authorizeOwner resolves userID 0 to the caller and checks that the caller
+// may manage the keys of userID. Keys cannot manage keys.
+func (u *APIKeyUseCase) authorizeOwner(ctx context.Context, userID uint) (uint, error) {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if userID == 0 {
+		userID = principal.UserID
+	}
+	if userID != principal.UserID && !principal.HasPermission(entity.PermissionAPIKeysManage) {
+		return 0, ErrPermissionDenied
+	}
+	return userID, nil
+}
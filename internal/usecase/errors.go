@@ -0,0 +1,39 @@
+package usecase
+
+import "errors"
+
+var (
+	ErrUsernameExists      = errors.New("username already exists")
+	ErrEmailExists         = errors.New("email already exists")
+	ErrInvalidCredentials  = errors.New("invalid credentials")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reused")
+	ErrUnauthenticated     = errors.New("unauthenticated")
+	ErrPermissionDenied    = errors.New("permission denied")
+	ErrRoleNotFound        = errors.New("role not found")
+	ErrRoleExists          = errors.New("role already exists")
+	ErrRoleInUse           = errors.New("role is assigned to users")
+	ErrBuiltInRole         = errors.New("built-in role cannot be modified")
+	ErrUnknownPermission   = errors.New("unknown permission")
+	ErrInvalidResetToken   = errors.New("invalid password reset token")
+	ErrInvalidVerifyToken  = errors.New("invalid email verification token")
+	ErrEmailNotVerified    = errors.New("email not verified")
+	ErrMFAUnavailable      = errors.New("two-factor authentication is not configured")
+	ErrTOTPAlreadyEnabled  = errors.New("totp already enabled")
+	ErrTOTPNotEnrolled     = errors.New("totp not enrolled")
+	ErrTOTPNotEnabled      = errors.New("totp not enabled")
+	ErrInvalidMFACode      = errors.New("invalid mfa code")
+	ErrInvalidMFAToken     = errors.New("invalid mfa token")
+	ErrSuspendSelf         = errors.New("cannot suspend own account")
+	ErrInvalidStatusExpiry = errors.New("status expiry must be in the future")
+	ErrScopeNotGranted     = errors.New("scope not granted to the key's user")
+	ErrInvalidAPIKeyExpiry = errors.New("api key expiry must be in the future")
+	ErrUnknownGrantType    = errors.New("unknown oauth grant type")
+	ErrInvalidRedirectURI  = errors.New("invalid oauth redirect uri")
+	ErrUnknownOIDCProvider = errors.New("unknown oidc provider")
+	ErrOIDCLoginRejected   = errors.New("oidc login rejected")
+
+	ErrInvalidCurrentPassword  = errors.New("invalid current password")
+	ErrTooManyResetRequests    = errors.New("too many password reset requests")
+	ErrPublicClientCredentials = errors.New("public oauth clients cannot use client credentials")
+)
@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/config"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/repository"
+)
+
+// LoginThrottledError is returned while a username or client IP has to wait
+// before the next login attempt.
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+	Locked     bool
+}
+
+func (e *LoginThrottledError) Error() string {
+	return fmt.Sprintf("too many failed login attempts, retry after %s", e.RetryAfter)
+}
+
+// IsLoginThrottled unwraps a LoginThrottledError from err.
+func IsLoginThrottled(err error) (*LoginThrottledError, bool) {
+	var throttled *LoginThrottledError
+	ok := errors.As(err, &throttled)
+	return throttled, ok
+}
+
+// LoginGuard applies progressive backoff and temporary lockout to failed
+// logins, per username and per client IP.
+type LoginGuard struct {
+	repo repository.LoginThrottleRepository
+	cfg  config.LoginThrottleConfig
+	now  func() time.Time
+}
+
+func NewLoginGuard(repo repository.LoginThrottleRepository, cfg config.LoginThrottleConfig) *LoginGuard {
+	return &LoginGuard{repo: repo, cfg: cfg, now: time.Now}
+}
+
+const (
+	usernameThrottlePrefix = "user:"
+	ipThrottlePrefix       = "ip:"
+)
+
+func usernameThrottleKey(username string) string {
+	return usernameThrottlePrefix + username
+}
+
+func ipThrottleKey(ip string) string {
+	return ipThrottlePrefix + ip
+}
+
+func (g *LoginGuard) keys(username, clientIP string) []string {
+	keys := []string{usernameThrottleKey(username)}
+	if clientIP != "" {
+		keys = append(keys, ipThrottleKey(clientIP))
+	}
+	return keys
+}
+
+// Reserve counts an attempt against username and clientIP before the
+// credentials are checked, or returns a LoginThrottledError when any key is
+// still waiting out a backoff delay or a lock. Checking and counting happen
+// together, so parallel attempts cannot all slip past the limits. The
+// attempt stays counted as failed unless it is handed back with Release.
+func (g *LoginGuard) Reserve(username, clientIP string) error {
+	now := g.now()
+	return g.repo.Reserve(g.keys(username, clientIP), now, g.cfg.FailureWindow, func(throttles []*entity.LoginThrottle) error {
+		if err := g.throttled(throttles, now); err != nil {
+			return err
+		}
+		// Attempts still in flight count as failures already, and must not
+		// carry a key past its lock threshold. The one attempt after a lock
+		// ends is let through, to lock again if it fails.
+		for _, throttle := range throttles {
+			lockEnded := throttle.LockedUntil != nil && throttle.LockedUntil.After(throttle.LastFailedAt)
+			if throttle.FailedCount >= g.lockThreshold(throttle.ThrottleKey) && !lockEnded {
+				return &LoginThrottledError{RetryAfter: g.cfg.BackoffBase}
+			}
+		}
+		return nil
+	})
+}
+
+// Release hands back an attempt counted by Reserve that did not fail, such
+// as one with the right password.
+func (g *LoginGuard) Release(username, clientIP string) error {
+	for _, key := range g.keys(username, clientIP) {
+		if err := g.repo.Release(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// throttled returns a LoginThrottledError with the longest wait among
+// throttles, or nil when none has to wait.
+func (g *LoginGuard) throttled(throttles []*entity.LoginThrottle, now time.Time) error {
+	var result *LoginThrottledError
+	for _, throttle := range throttles {
+		if now.Sub(throttle.LastFailedAt) > g.cfg.FailureWindow && (throttle.LockedUntil == nil || now.After(*throttle.LockedUntil)) {
+			continue
+		}
+
+		var until time.Time
+		locked := false
+		if throttle.LockedUntil != nil && now.Before(*throttle.LockedUntil) {
+			until, locked = *throttle.LockedUntil, true
+		} else {
+			until = throttle.LastFailedAt.Add(g.backoff(throttle))
+		}
+
+		if retryAfter := until.Sub(now); retryAfter > 0 && (result == nil || retryAfter > result.RetryAfter) {
+			result = &LoginThrottledError{RetryAfter: retryAfter, Locked: locked}
+		}
+	}
+
+	if result == nil {
+		return nil
+	}
+	return result
+}
+
+// RecordFailure confirms an attempt counted by Reserve as failed, and locks
+// the keys that reached their lock threshold.
+func (g *LoginGuard) RecordFailure(username, clientIP string) error {
+	throttles, err := g.repo.FindByKeys(g.keys(username, clientIP))
+	if err != nil {
+		return err
+	}
+	now := g.now()
+	for _, throttle := range throttles {
+		if throttle.FailedCount >= g.lockThreshold(throttle.ThrottleKey) {
+			if err := g.repo.Lock(throttle.ThrottleKey, now.Add(g.cfg.LockDuration)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// RecordSuccess clears the username counter. The IP counter is left to
+// expire on its own so one valid account cannot launder a credential
+// stuffing run from the same address.
+func (g *LoginGuard) RecordSuccess(username string) error {
+	return g.repo.Reset(usernameThrottleKey(username))
+}
+
+// Unlock clears any backoff or lock on the username, and on clientIP when
+// it is set. The user's failures count against their address as well, so
+// clearing only the username can leave them locked out.
+func (g *LoginGuard) Unlock(username, clientIP string) error {
+	for _, key := range g.keys(username, clientIP) {
+		if err := g.repo.Reset(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backoff returns how long after its last failure a key must wait: nothing
+// within the free attempts, then BackoffBase doubling up to BackoffMax.
+func (g *LoginGuard) backoff(throttle *entity.LoginThrottle) time.Duration {
+	excess := throttle.FailedCount - g.freeAttempts(throttle.ThrottleKey)
+	if excess <= 0 {
+		return 0
+	}
+	delay := g.cfg.BackoffBase
+	for i := 1; i < excess && delay < g.cfg.BackoffMax; i++ {
+		delay *= 2
+	}
+	if delay > g.cfg.BackoffMax {
+		delay = g.cfg.BackoffMax
+	}
+	return delay
+}
+
+func (g *LoginGuard) freeAttempts(key string) int {
+	if strings.HasPrefix(key, usernameThrottlePrefix) {
+		return g.cfg.FreeAttempts
+	}
+	return g.cfg.IPFreeAttempts
+}
+
+func (g *LoginGuard) lockThreshold(key string) int {
+	if strings.HasPrefix(key, usernameThrottlePrefix) {
+		return g.cfg.LockThreshold
+	}
+	return g.cfg.IPLockThreshold
+}
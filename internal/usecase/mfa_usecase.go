@@ -0,0 +1,360 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/config"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// MFAUseCase manages TOTP two-factor authentication and the short-lived
+// challenges that bridge the two login steps.
+type MFAUseCase struct {
+	mfaRepo   repository.MFARepository
+	userRepo  repository.UserRepository
+	tokenRepo repository.OneTimeTokenRepository
+	// box is nil when no encryption key is configured
+	box *infrastructure.SecretBox
+	cfg config.MFAConfig
+	now func() time.Time
+}
+
+func NewMFAUseCase(mfaRepo repository.MFARepository, userRepo repository.UserRepository, tokenRepo repository.OneTimeTokenRepository, cfg config.MFAConfig) (*MFAUseCase, error) {
+	var box *infrastructure.SecretBox
+	if cfg.EncryptionKey == "" {
+		log.Println("No MFA encryption key configured, two-factor enrollment is disabled")
+	} else {
+		var err error
+		box, err = infrastructure.NewSecretBox(cfg.EncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("mfa encryption key: %w", err)
+		}
+	}
+
+	return &MFAUseCase{
+		mfaRepo:   mfaRepo,
+		userRepo:  userRepo,
+		tokenRepo: tokenRepo,
+		box:       box,
+		cfg:       cfg,
+		now:       time.Now,
+	}, nil
+}
+
+// TOTPEnrollment is the secret to load into an authenticator app.
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// MFAChallenge is handed out by Login in place of tokens when the user has
+// two-factor authentication enabled.
+type MFAChallenge struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// EnrollTOTP starts enrollment with a new secret. It only takes effect after
+// ConfirmTOTP, so an abandoned enrollment never locks the user out.
+func (u *MFAUseCase) EnrollTOTP(ctx context.Context) (*TOTPEnrollment, error) {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if u.box == nil {
+		return nil, ErrMFAUnavailable
+	}
+
+	existing, err := u.mfaRepo.FindTOTP(principal.UserID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err == nil && existing.ConfirmedAt != nil {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	user, err := u.userRepo.FindByID(ctx, int(principal.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := infrastructure.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	ciphertext, err := u.box.Seal(secret, totpAdditionalData(user.ID))
+	if err != nil {
+		return nil, err
+	}
+	if err := u.mfaRepo.SaveTOTP(&entity.TOTPCredential{
+		UserID:           user.ID,
+		SecretCiphertext: ciphertext,
+	}); err != nil {
+		return nil, err
+	}
+
+	return &TOTPEnrollment{
+		Secret: infrastructure.EncodeTOTPSecret(secret),
+		URI:    infrastructure.TOTPURI(u.cfg.Issuer, user.Username, secret),
+	}, nil
+}
+
+// ConfirmTOTP activates a pending enrollment with a first valid code and
+// returns the initial recovery codes.
+func (u *MFAUseCase) ConfirmTOTP(ctx context.Context, code string) ([]string, error) {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := u.mfaRepo.FindTOTP(principal.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrTOTPNotEnrolled
+		}
+		return nil, err
+	}
+	if credential.ConfirmedAt != nil {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+
+	step, ok, err := u.validateTOTP(credential, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+	if err := u.mfaRepo.ConfirmTOTP(credential.ID, step); err != nil {
+		return nil, err
+	}
+
+	return u.replaceRecoveryCodes(principal.UserID)
+}
+
+// DisableTOTP turns two-factor authentication off. Users disabling their own
+// must prove possession with a TOTP or recovery code; holders of
+// users:reset_mfa may disable it for others, e.g. after a lost device. API
+// keys can do neither.
+func (u *MFAUseCase) DisableTOTP(ctx context.Context, userID uint, code string) error {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return err
+	}
+	if userID == 0 {
+		userID = principal.UserID
+	}
+	if userID != principal.UserID && !principal.HasPermission(entity.PermissionUsersResetMFA) {
+		return ErrPermissionDenied
+	}
+
+	enabled, err := u.Enabled(userID)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return ErrTOTPNotEnabled
+	}
+
+	if userID == principal.UserID {
+		ok, err := u.verifyCode(userID, code)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrInvalidMFACode
+		}
+	}
+
+	return u.mfaRepo.DeleteTOTP(userID)
+}
+
+// RegenerateRecoveryCodes invalidates all recovery codes of the caller and
+// returns a new set.
+func (u *MFAUseCase) RegenerateRecoveryCodes(ctx context.Context, code string) ([]string, error) {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled, err := u.Enabled(principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, ErrTOTPNotEnabled
+	}
+
+	ok, err := u.verifyCode(principal.UserID, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	return u.replaceRecoveryCodes(principal.UserID)
+}
+
+// Enabled reports whether the user has a confirmed TOTP credential.
+func (u *MFAUseCase) Enabled(userID uint) (bool, error) {
+	credential, err := u.mfaRepo.FindTOTP(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return credential.ConfirmedAt != nil, nil
+}
+
+// createChallenge issues the token that VerifyMFA exchanges for an
+// AuthResponse once the second factor checks out.
+func (u *MFAUseCase) createChallenge(userID uint) (*MFAChallenge, error) {
+	token, err := infrastructure.GenerateOpaqueToken(32)
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := u.now().Add(u.cfg.ChallengeTTL)
+	if err := u.tokenRepo.Create(&entity.OneTimeToken{
+		UserID:    userID,
+		Purpose:   entity.TokenPurposeMFAChallenge,
+		TokenHash: infrastructure.HashToken(token),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return nil, err
+	}
+	return &MFAChallenge{Token: token, ExpiresAt: expiresAt}, nil
+}
+
+// findChallenge returns the unused, unexpired challenge for token.
+func (u *MFAUseCase) findChallenge(token string) (*entity.OneTimeToken, error) {
+	stored, err := u.tokenRepo.FindByTokenHash(entity.TokenPurposeMFAChallenge, infrastructure.HashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidMFAToken
+		}
+		return nil, err
+	}
+	if stored.UsedAt != nil || u.now().After(stored.ExpiresAt) {
+		return nil, ErrInvalidMFAToken
+	}
+	return stored, nil
+}
+
+// consumeChallenge marks a challenge used, failing if a concurrent request
+// already did.
+func (u *MFAUseCase) consumeChallenge(challenge *entity.OneTimeToken) error {
+	marked, err := u.tokenRepo.MarkUsed(challenge.ID)
+	if err != nil {
+		return err
+	}
+	if !marked {
+		return ErrInvalidMFAToken
+	}
+	return nil
+}
+
+// verifyCode accepts either a current TOTP code or an unused recovery code.
+// Both are single-use.
+func (u *MFAUseCase) verifyCode(userID uint, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if code == "" {
+		return false, nil
+	}
+
+	if isTOTPCode(code) {
+		credential, err := u.mfaRepo.FindTOTP(userID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return false, nil
+			}
+			return false, err
+		}
+		if credential.ConfirmedAt == nil {
+			return false, nil
+		}
+		step, ok, err := u.validateTOTP(credential, code)
+		if err != nil || !ok {
+			return false, err
+		}
+		// Replayed codes are rejected
+		return u.mfaRepo.AdvanceTOTPStep(credential.ID, step)
+	}
+
+	return u.mfaRepo.UseRecoveryCode(userID, infrastructure.HashToken(normalizeRecoveryCode(code)))
+}
+
+func (u *MFAUseCase) validateTOTP(credential *entity.TOTPCredential, code string) (int64, bool, error) {
+	if u.box == nil {
+		return 0, false, ErrMFAUnavailable
+	}
+	secret, err := u.box.Open(credential.SecretCiphertext, totpAdditionalData(credential.UserID))
+	if err != nil {
+		return 0, false, fmt.Errorf("decrypt totp secret: %w", err)
+	}
+	step, ok := infrastructure.ValidateTOTP(secret, code, u.now())
+	return step, ok, nil
+}
+
+func (u *MFAUseCase) replaceRecoveryCodes(userID uint) ([]string, error) {
+	codes := make([]string, 0, u.cfg.RecoveryCodeCount)
+	hashes := make([]string, 0, u.cfg.RecoveryCodeCount)
+	for i := 0; i < u.cfg.RecoveryCodeCount; i++ {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, infrastructure.HashToken(normalizeRecoveryCode(code)))
+	}
+	if err := u.mfaRepo.ReplaceRecoveryCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateRecoveryCode returns a code such as "k3vx7-q2mtp" (50 bits).
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 7)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
+
+func isTOTPCode(code string) bool {
+	if len(code) != 6 {
+		return false
+	}
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// totpAdditionalData binds an encrypted secret to its user.
+func totpAdditionalData(userID uint) []byte {
+	return []byte(fmt.Sprintf("totp:%d", userID))
+}
@@ -0,0 +1,650 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"log"
+	"net"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/config"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/repository"
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+// OAuth error codes of RFC 6749 and RFC 6750.
+const (
+	OAuthInvalidRequest          = "invalid_request"
+	OAuthInvalidClient           = "invalid_client"
+	OAuthInvalidGrant            = "invalid_grant"
+	OAuthInvalidScope            = "invalid_scope"
+	OAuthUnauthorizedClient      = "unauthorized_client"
+	OAuthUnsupportedGrantType    = "unsupported_grant_type"
+	OAuthUnsupportedResponseType = "unsupported_response_type"
+	OAuthInsufficientScope       = "insufficient_scope"
+)
+
+// OAuthError is an error response of the OAuth endpoints. When RedirectURI
+// is set the error is reported to the client by redirecting the user there.
+type OAuthError struct {
+	Code        string
+	Description string
+	RedirectURI string
+	State       string
+}
+
+func (e *OAuthError) Error() string {
+	return e.Code + ": " + e.Description
+}
+
+// Location returns the URL that reports the error to the client, or "" when
+// it must be shown to the user instead.
+func (e *OAuthError) Location() string {
+	if e.RedirectURI == "" {
+		return ""
+	}
+	params := url.Values{"error": {e.Code}, "error_description": {e.Description}}
+	if e.State != "" {
+		params.Set("state", e.State)
+	}
+	return withQuery(e.RedirectURI, params)
+}
+
+// IsOAuthError reports whether err is an OAuthError and returns it.
+func IsOAuthError(err error) (*OAuthError, bool) {
+	var oauthErr *OAuthError
+	ok := errors.As(err, &oauthErr)
+	return oauthErr, ok
+}
+
+// OAuthUseCase is the built-in OAuth 2.0 and OpenID Connect provider. Users
+// authorize clients with the authorization code grant and PKCE; clients
+// then refresh their tokens like first-party apps do, in a session of
+// their own. Confidential clients may also get tokens for themselves with
+// the client credentials grant.
+//
+// Access tokens issued to clients are only accepted by the OAuth endpoints,
+// such as UserInfo; they carry the scopes the user granted, not the
+// permissions of the user's role.
+type OAuthUseCase struct {
+	oauthRepo        repository.OAuthRepository
+	userRepo         repository.UserRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	sessions         *SessionUseCase
+	verification     *EmailVerificationUseCase
+	jwt              *infrastructure.JWTManager
+	refreshTokenTTL  time.Duration
+	cfg              config.OAuthConfig
+}
+
+func NewOAuthUseCase(oauthRepo repository.OAuthRepository, userRepo repository.UserRepository, refreshTokenRepo repository.RefreshTokenRepository, sessions *SessionUseCase, verification *EmailVerificationUseCase, jwt *infrastructure.JWTManager, refreshTokenTTL time.Duration, cfg config.OAuthConfig) *OAuthUseCase {
+	return &OAuthUseCase{
+		oauthRepo:        oauthRepo,
+		userRepo:         userRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		sessions:         sessions,
+		verification:     verification,
+		jwt:              jwt,
+		refreshTokenTTL:  refreshTokenTTL,
+		cfg:              cfg,
+	}
+}
+
+// OAuthClientRegistration describes a client to register.
+type OAuthClientRegistration struct {
+	Name         string
+	RedirectURIs []string
+	GrantTypes   []string
+	Scopes       []string
+	// Public clients, such as single-page and native apps, cannot keep a
+	// secret and get none.
+	Public bool
+}
+
+// CreateClient registers a client. The returned secret is not stored and
+// cannot be shown again; it is empty for public clients.
+func (u *OAuthUseCase) CreateClient(ctx context.Context, registration OAuthClientRegistration) (*entity.OAuthClient, string, error) {
+	if _, err := requirePermission(ctx, entity.PermissionOAuthClientsManage); err != nil {
+		return nil, "", err
+	}
+
+	for _, grantType := range registration.GrantTypes {
+		switch grantType {
+		case entity.OAuthGrantAuthorizationCode, entity.OAuthGrantRefreshToken:
+		case entity.OAuthGrantClientCredentials:
+			if registration.Public {
+				return nil, "", ErrPublicClientCredentials
+			}
+		default:
+			return nil, "", ErrUnknownGrantType
+		}
+	}
+	usesRedirects := slices.Contains(registration.GrantTypes, entity.OAuthGrantAuthorizationCode)
+	if usesRedirects && len(registration.RedirectURIs) == 0 {
+		return nil, "", ErrInvalidRedirectURI
+	}
+	for _, redirectURI := range registration.RedirectURIs {
+		if !validRedirectURI(redirectURI) {
+			return nil, "", ErrInvalidRedirectURI
+		}
+	}
+
+	clientID, err := infrastructure.GenerateOpaqueToken(16)
+	if err != nil {
+		return nil, "", err
+	}
+	client := &entity.OAuthClient{
+		ClientID:     clientID,
+		Name:         registration.Name,
+		RedirectURIs: registration.RedirectURIs,
+		GrantTypes:   registration.GrantTypes,
+		Scopes:       registration.Scopes,
+	}
+	var secret string
+	if !registration.Public {
+		secret, err = infrastructure.GenerateOpaqueToken(32)
+		if err != nil {
+			return nil, "", err
+		}
+		client.SecretHash = infrastructure.HashToken(secret)
+	}
+	if err := u.oauthRepo.CreateClient(client); err != nil {
+		return nil, "", err
+	}
+	return client, secret, nil
+}
+
+func (u *OAuthUseCase) ListClients(ctx context.Context) ([]*entity.OAuthClient, error) {
+	if _, err := requirePermission(ctx, entity.PermissionOAuthClientsManage); err != nil {
+		return nil, err
+	}
+	return u.oauthRepo.ListClients()
+}
+
+// DeleteClient removes a client. Its refresh tokens stop working at once,
+// its access tokens when they expire.
+func (u *OAuthUseCase) DeleteClient(ctx context.Context, id uint) error {
+	if _, err := requirePermission(ctx, entity.PermissionOAuthClientsManage); err != nil {
+		return err
+	}
+	if _, err := u.oauthRepo.FindClientByID(id); err != nil {
+		return err
+	}
+	return u.oauthRepo.DeleteClient(id)
+}
+
+// AuthorizationRequest is an authorization code request with PKCE, as
+// received by the authorization endpoint.
+type AuthorizationRequest struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// Authorize lets the signed-in caller authorize a client, starting a
+// session for it, and returns the URL to redirect the user to with the
+// authorization code. The caller must have signed in with Login, so that
+// the sign-in UI can forward the user's access token.
+func (u *OAuthUseCase) Authorize(ctx context.Context, req AuthorizationRequest, client ClientInfo) (string, error) {
+	oauthClient, err := u.oauthRepo.FindClient(req.ClientID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", &OAuthError{Code: OAuthInvalidRequest, Description: "unknown client_id"}
+		}
+		return "", err
+	}
+	redirectURI := req.RedirectURI
+	if redirectURI == "" && len(oauthClient.RedirectURIs) == 1 {
+		redirectURI = oauthClient.RedirectURIs[0]
+	}
+	// An unregistered redirect URI must never see the outcome
+	if !oauthClient.AllowsRedirectURI(redirectURI) {
+		return "", &OAuthError{Code: OAuthInvalidRequest, Description: "redirect_uri is not registered for the client"}
+	}
+
+	redirectError := func(code, description string) error {
+		return &OAuthError{Code: code, Description: description, RedirectURI: redirectURI, State: req.State}
+	}
+	if req.ResponseType != "code" {
+		return "", redirectError(OAuthUnsupportedResponseType, "only the code response type is supported")
+	}
+	if !oauthClient.AllowsGrant(entity.OAuthGrantAuthorizationCode) {
+		return "", redirectError(OAuthUnauthorizedClient, "the client may not use the authorization code grant")
+	}
+	if req.CodeChallenge == "" {
+		return "", redirectError(OAuthInvalidRequest, "code_challenge is required")
+	}
+	if req.CodeChallengeMethod != "S256" {
+		return "", redirectError(OAuthInvalidRequest, "code_challenge_method must be S256")
+	}
+	scope, ok := grantScope(req.Scope, oauthClient.Scopes)
+	if !ok {
+		return "", redirectError(OAuthInvalidScope, "scope is not registered for the client")
+	}
+
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// A session lives as long as its refresh tokens. Clients without them
+	// get none, and their access tokens end on their own.
+	familyID := ""
+	if oauthClient.AllowsGrant(entity.OAuthGrantRefreshToken) {
+		familyID, err = u.sessions.startOAuth(principal.UserID, client, oauthClient.ClientID, scope)
+		if err != nil {
+			return "", err
+		}
+	}
+	code, err := infrastructure.GenerateOpaqueToken(32)
+	if err != nil {
+		return "", err
+	}
+	if err := u.oauthRepo.CreateCode(&entity.OAuthAuthorizationCode{
+		CodeHash:      infrastructure.HashToken(code),
+		ClientID:      oauthClient.ClientID,
+		UserID:        principal.UserID,
+		FamilyID:      familyID,
+		RedirectURI:   req.RedirectURI,
+		Scope:         scope,
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+		ExpiresAt:     time.Now().Add(u.cfg.CodeTTL),
+	}); err != nil {
+		return "", err
+	}
+
+	params := url.Values{"code": {code}}
+	if req.State != "" {
+		params.Set("state", req.State)
+	}
+	return withQuery(redirectURI, params), nil
+}
+
+// TokenRequest is a request to the token endpoint. The client credentials
+// may come from HTTP basic authentication or the request body.
+type TokenRequest struct {
+	GrantType    string
+	ClientID     string
+	ClientSecret string
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+	RefreshToken string
+	Scope        string
+}
+
+// OAuthTokens is a successful token response. RefreshToken and IDToken are
+// empty when not issued.
+type OAuthTokens struct {
+	AccessToken  string
+	ExpiresAt    time.Time
+	RefreshToken string
+	IDToken      string
+	Scope        string
+}
+
+// Token serves the token endpoint for the authorization code, refresh
+// token and client credentials grants.
+func (u *OAuthUseCase) Token(ctx context.Context, req TokenRequest) (*OAuthTokens, error) {
+	client, err := u.authenticateClient(req.ClientID, req.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	switch req.GrantType {
+	case entity.OAuthGrantAuthorizationCode, entity.OAuthGrantRefreshToken, entity.OAuthGrantClientCredentials:
+	default:
+		return nil, &OAuthError{Code: OAuthUnsupportedGrantType, Description: "unsupported grant_type"}
+	}
+	if !client.AllowsGrant(req.GrantType) {
+		return nil, &OAuthError{Code: OAuthUnauthorizedClient, Description: "the client may not use this grant"}
+	}
+
+	switch req.GrantType {
+	case entity.OAuthGrantAuthorizationCode:
+		return u.exchangeCode(ctx, client, req)
+	case entity.OAuthGrantRefreshToken:
+		return u.refresh(ctx, client, req)
+	default:
+		return u.clientCredentials(client, req)
+	}
+}
+
+// UserInfo returns the claims about the user of an access token issued to a
+// client, as released by the token's scopes.
+func (u *OAuthUseCase) UserInfo(ctx context.Context, token string) (*UserInfo, error) {
+	claims, err := u.jwt.ParseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if claims.ClientID == "" || claims.UserID == 0 {
+		return nil, infrastructure.ErrInvalidToken
+	}
+	scopes := strings.Fields(claims.Scope)
+	if !slices.Contains(scopes, entity.OAuthScopeOpenID) {
+		return nil, &OAuthError{Code: OAuthInsufficientScope, Description: "the openid scope is required"}
+	}
+	if _, err := u.oauthRepo.FindClient(claims.ClientID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, infrastructure.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	user, err := tokenUser(ctx, u.userRepo, u.sessions, claims)
+	if err != nil {
+		return nil, err
+	}
+	return &UserInfo{Subject: claims.Subject, OIDCUserClaims: userClaims(user, scopes)}, nil
+}
+
+// UserInfo is the response of the userinfo endpoint.
+type UserInfo struct {
+	Subject string `json:"sub"`
+	infrastructure.OIDCUserClaims
+}
+
+// Issuer is the provider's issuer identifier, the base of its endpoints.
+func (u *OAuthUseCase) Issuer() string {
+	return u.cfg.Issuer
+}
+
+func (u *OAuthUseCase) authenticateClient(clientID, secret string) (*entity.OAuthClient, error) {
+	invalid := &OAuthError{Code: OAuthInvalidClient, Description: "client authentication failed"}
+	if clientID == "" {
+		return nil, invalid
+	}
+	client, err := u.oauthRepo.FindClient(clientID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, invalid
+		}
+		return nil, err
+	}
+	if client.Public() {
+		if secret != "" {
+			return nil, invalid
+		}
+		return client, nil
+	}
+	if subtle.ConstantTimeCompare([]byte(infrastructure.HashToken(secret)), []byte(client.SecretHash)) != 1 {
+		return nil, invalid
+	}
+	return client, nil
+}
+
+func (u *OAuthUseCase) exchangeCode(ctx context.Context, client *entity.OAuthClient, req TokenRequest) (*OAuthTokens, error) {
+	invalid := &OAuthError{Code: OAuthInvalidGrant, Description: "invalid authorization code"}
+	code, err := u.oauthRepo.FindCode(infrastructure.HashToken(req.Code))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, invalid
+		}
+		return nil, err
+	}
+	if code.ClientID != client.ClientID {
+		return nil, invalid
+	}
+	// A replayed code may have been intercepted, so its tokens go too
+	if code.UsedAt != nil {
+		return nil, u.revokeReusedCode(code)
+	}
+	if time.Now().After(code.ExpiresAt) {
+		return nil, invalid
+	}
+	if req.RedirectURI != code.RedirectURI {
+		return nil, &OAuthError{Code: OAuthInvalidGrant, Description: "redirect_uri does not match the authorization request"}
+	}
+	if !verifyCodeChallenge(req.CodeVerifier, code.CodeChallenge) {
+		return nil, &OAuthError{Code: OAuthInvalidGrant, Description: "code_verifier does not match the code_challenge"}
+	}
+	marked, err := u.oauthRepo.MarkCodeUsed(code.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, u.revokeReusedCode(code)
+	}
+
+	user, err := u.activeUser(ctx, code.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return u.issueTokens(client, user, code.FamilyID, code.Scope, code.Nonce)
+}
+
+func (u *OAuthUseCase) refresh(ctx context.Context, client *entity.OAuthClient, req TokenRequest) (*OAuthTokens, error) {
+	invalid := &OAuthError{Code: OAuthInvalidGrant, Description: "invalid refresh token"}
+	var session *entity.Session
+	scope := ""
+	stored, err := redeemRefreshToken(u.refreshTokenRepo, req.RefreshToken, func(stored *entity.RefreshToken) error {
+		var err error
+		session, err = u.sessions.find(stored.FamilyID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return invalid
+			}
+			return err
+		}
+		if session.ClientID != client.ClientID {
+			return invalid
+		}
+
+		// The client may ask for less than the user granted, never more
+		scope = session.Scope
+		if req.Scope != "" {
+			narrowed, ok := grantScope(req.Scope, strings.Fields(session.Scope))
+			if !ok {
+				return &OAuthError{Code: OAuthInvalidScope, Description: "scope exceeds the granted scope"}
+			}
+			scope = narrowed
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, ErrInvalidRefreshToken) || errors.Is(err, ErrRefreshTokenReused) {
+			return nil, invalid
+		}
+		return nil, err
+	}
+
+	user, err := u.activeUser(ctx, stored.UserID)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := u.issueTokens(client, user, stored.FamilyID, scope, "")
+	if err != nil {
+		return nil, err
+	}
+	if err := u.sessions.refreshed(stored.FamilyID); err != nil {
+		log.Printf("Failed to record refresh of session %d: %v", session.ID, err)
+	}
+	return tokens, nil
+}
+
+func (u *OAuthUseCase) clientCredentials(client *entity.OAuthClient, req TokenRequest) (*OAuthTokens, error) {
+	// Public clients cannot prove who they are
+	if client.Public() {
+		return nil, &OAuthError{Code: OAuthUnauthorizedClient, Description: "public clients cannot use client credentials"}
+	}
+	scope, ok := grantScope(req.Scope, client.Scopes)
+	if !ok {
+		return nil, &OAuthError{Code: OAuthInvalidScope, Description: "scope is not registered for the client"}
+	}
+	accessToken, expiresAt, err := u.jwt.GenerateOAuthJWT(u.cfg.Issuer, 0, "", client.ClientID, scope)
+	if err != nil {
+		return nil, err
+	}
+	return &OAuthTokens{AccessToken: accessToken, ExpiresAt: expiresAt, Scope: scope}, nil
+}
+
+// activeUser returns the user tokens are issued to, who must still be
+// allowed to sign in.
+func (u *OAuthUseCase) activeUser(ctx context.Context, userID uint) (*entity.User, error) {
+	user, err := u.userRepo.FindByID(ctx, int(userID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, &OAuthError{Code: OAuthInvalidGrant, Description: "the user no longer exists"}
+		}
+		return nil, err
+	}
+	if u.verification.BlocksLogin(user) {
+		return nil, &OAuthError{Code: OAuthInvalidGrant, Description: "the user's email address is not verified"}
+	}
+	if err := checkAccountStatus(user, time.Now()); err != nil {
+		return nil, &OAuthError{Code: OAuthInvalidGrant, Description: "the user's account is not active"}
+	}
+	return user, nil
+}
+
+// issueTokens issues an access token, a refresh token in the session of
+// familyID when the client has the refresh token grant, and an ID token when
+// the openid scope was granted. The access token names the session only
+// along with a refresh token, since the session ends without one.
+func (u *OAuthUseCase) issueTokens(client *entity.OAuthClient, user *entity.User, familyID, scope, nonce string) (*OAuthTokens, error) {
+	var refreshToken string
+	if familyID != "" && client.AllowsGrant(entity.OAuthGrantRefreshToken) {
+		var err error
+		refreshToken, _, err = issueRefreshToken(u.refreshTokenRepo, user.ID, familyID, u.refreshTokenTTL)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		familyID = ""
+	}
+	accessToken, expiresAt, err := u.jwt.GenerateOAuthJWT(u.cfg.Issuer, int(user.ID), familyID, client.ClientID, scope)
+	if err != nil {
+		return nil, err
+	}
+	tokens := &OAuthTokens{
+		AccessToken:  accessToken,
+		ExpiresAt:    expiresAt,
+		RefreshToken: refreshToken,
+		Scope:        scope,
+	}
+
+	scopes := strings.Fields(scope)
+	if slices.Contains(scopes, entity.OAuthScopeOpenID) {
+		now := time.Now()
+		tokens.IDToken, err = u.jwt.SignIDToken(&infrastructure.IDTokenClaim{
+			Nonce:           nonce,
+			AuthorizedParty: client.ClientID,
+			OIDCUserClaims:  userClaims(user, scopes),
+			RegisteredClaims: jwt.RegisteredClaims{
+				Issuer:    u.cfg.Issuer,
+				Subject:   strconv.Itoa(int(user.ID)),
+				Audience:  jwt.ClaimStrings{client.ClientID},
+				IssuedAt:  jwt.NewNumericDate(now),
+				ExpiresAt: jwt.NewNumericDate(expiresAt),
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tokens, nil
+}
+
+func (u *OAuthUseCase) revokeReusedCode(code *entity.OAuthAuthorizationCode) error {
+	if code.FamilyID != "" {
+		if err := u.refreshTokenRepo.RevokeFamily(code.FamilyID); err != nil {
+			return err
+		}
+	}
+	return &OAuthError{Code: OAuthInvalidGrant, Description: "authorization code already used"}
+}
+
+// grantScope checks the requested space-separated scopes against the
+// allowed ones. An empty request is granted everything allowed.
+func grantScope(requested string, allowed []string) (string, bool) {
+	scopes := strings.Fields(requested)
+	if len(scopes) == 0 {
+		return strings.Join(allowed, " "), true
+	}
+	var granted []string
+	for _, scope := range scopes {
+		if !slices.Contains(allowed, scope) {
+			return "", false
+		}
+		if !slices.Contains(granted, scope) {
+			granted = append(granted, scope)
+		}
+	}
+	return strings.Join(granted, " "), true
+}
+
+// userClaims releases the claims of the profile and email scopes.
+func userClaims(user *entity.User, scopes []string) infrastructure.OIDCUserClaims {
+	var claims infrastructure.OIDCUserClaims
+	if slices.Contains(scopes, entity.OAuthScopeProfile) {
+		claims.Name = user.Name
+		claims.PreferredUsername = user.Username
+	}
+	if slices.Contains(scopes, entity.OAuthScopeEmail) && user.Email != nil && *user.Email != "" {
+		verified := user.EmailVerifiedAt != nil
+		claims.Email = *user.Email
+		claims.EmailVerified = &verified
+	}
+	return claims
+}
+
+// verifyCodeChallenge checks a PKCE code verifier against an S256 challenge.
+func verifyCodeChallenge(verifier, challenge string) bool {
+	// RFC 7636 verifiers are 43 to 128 characters
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
+
+// validRedirectURI accepts absolute https URIs without a fragment. Plain
+// http is only good enough for loopback addresses, where native apps receive
+// the code on a local port (RFC 8252).
+func validRedirectURI(uri string) bool {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Host == "" || strings.Contains(uri, "#") {
+		return false
+	}
+	switch parsed.Scheme {
+	case "https":
+		return true
+	case "http":
+		return isLoopbackHost(parsed.Hostname())
+	}
+	return false
+}
+
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// withQuery appends params to a URI that may already have a query.
+func withQuery(uri string, params url.Values) string {
+	separator := "?"
+	if strings.Contains(uri, "?") {
+		separator = "&"
+	}
+	return uri + separator + params.Encode()
+}
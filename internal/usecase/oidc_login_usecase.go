@@ -0,0 +1,237 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// usernameAttempts is how many random suffixes are tried when the username
+// derived from an external identity is taken.
+const usernameAttempts = 10
+
+// OIDCLoginUseCase signs users in through external OpenID Connect
+// providers. The first sign-in with a provider account creates a local user,
+// or links an existing one by email address where the provider is trusted
+// to have verified it; later sign-ins find the user through the link.
+type OIDCLoginUseCase struct {
+	providers    map[string]*infrastructure.OIDCProvider
+	identityRepo repository.ExternalIdentityRepository
+	userRepo     repository.UserRepository
+	users        *UserUseCase
+}
+
+func NewOIDCLoginUseCase(providers map[string]*infrastructure.OIDCProvider, identityRepo repository.ExternalIdentityRepository, userRepo repository.UserRepository, users *UserUseCase) *OIDCLoginUseCase {
+	return &OIDCLoginUseCase{
+		providers:    providers,
+		identityRepo: identityRepo,
+		userRepo:     userRepo,
+		users:        users,
+	}
+}
+
+// LoginWithOIDC completes a sign-in the client started at provider: it
+// redeems the authorization code the provider redirected back with and
+// verifies the ID token. codeVerifier and nonce are those of the
+// authorization request, if it had them. Like Login, users with two-factor
+// authentication get an MFAChallenge instead of tokens.
+func (u *OIDCLoginUseCase) LoginWithOIDC(ctx context.Context, provider, code, codeVerifier, nonce string, client ClientInfo) (*AuthTokens, *MFAChallenge, error) {
+	p, ok := u.providers[provider]
+	if !ok {
+		return nil, nil, ErrUnknownOIDCProvider
+	}
+	identity, err := p.Authenticate(ctx, code, codeVerifier, nonce)
+	if err != nil {
+		if errors.Is(err, infrastructure.ErrOIDCRejected) {
+			log.Printf("Login with OIDC provider %s rejected: %v", provider, err)
+			return nil, nil, ErrOIDCLoginRejected
+		}
+		return nil, nil, err
+	}
+
+	user, err := u.linkedUser(ctx, provider, p, identity)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if u.users.verification.BlocksLogin(user) {
+		return nil, nil, ErrEmailNotVerified
+	}
+	if err := checkAccountStatus(user, time.Now()); err != nil {
+		return nil, nil, err
+	}
+	// The provider vouches for the user, not for their second factor here
+	mfaEnabled, err := u.users.mfa.Enabled(user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if mfaEnabled {
+		challenge, err := u.users.mfa.createChallenge(user.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, challenge, nil
+	}
+	tokens, err := u.users.startSession(user, client)
+	return tokens, nil, err
+}
+
+// linkedUser returns the user linked to identity, linking or creating one
+// on the first sign-in.
+func (u *OIDCLoginUseCase) linkedUser(ctx context.Context, provider string, p *infrastructure.OIDCProvider, identity *infrastructure.OIDCIdentity) (*entity.User, error) {
+	link, err := u.identityRepo.Find(provider, identity.Subject)
+	if err == nil {
+		user, err := u.userRepo.FindByID(ctx, int(link.UserID))
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrOIDCLoginRejected
+			}
+			return nil, err
+		}
+		if err := u.identityRepo.MarkLogin(link.ID, identity.Email, time.Now()); err != nil {
+			log.Printf("Failed to record login of external identity %d: %v", link.ID, err)
+		}
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	var user *entity.User
+	if identity.Email != "" {
+		existing, err := u.userRepo.FindByEmail(ctx, identity.Email)
+		switch {
+		case err == nil:
+			// Anyone can claim an address at a provider that doesn't verify
+			// it, so only trusted providers may take over an existing account.
+			// Nor may anyone who registered the address here without owning
+			// it, or they would know the password of the account linked.
+			if !p.TrustsEmail() || !identity.EmailVerified || existing.EmailVerifiedAt == nil {
+				return nil, ErrEmailExists
+			}
+			user = existing
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return nil, err
+		}
+	}
+	if user == nil {
+		if user, err = u.createUser(ctx, p, identity); err != nil {
+			return nil, err
+		}
+	}
+
+	now := time.Now()
+	if err := u.identityRepo.Create(&entity.ExternalIdentity{
+		UserID:      user.ID,
+		Provider:    provider,
+		Subject:     identity.Subject,
+		Email:       identity.Email,
+		LastLoginAt: &now,
+	}); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// createUser creates the account for a first sign-in. It has a random
+// password nobody knows; the user may set one through a password reset. The
+// email address only starts out verified when a trusted provider verified
+// it, otherwise the user is mailed a link like after registering.
+func (u *OIDCLoginUseCase) createUser(ctx context.Context, p *infrastructure.OIDCProvider, identity *infrastructure.OIDCIdentity) (*entity.User, error) {
+	username, err := u.availableUsername(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := infrastructure.GenerateOpaqueToken(32)
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := u.users.hasher.Hash(ctx, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	name := truncateRunes(strings.TrimSpace(identity.Name), 100)
+	if name == "" {
+		name = username
+	}
+	user := &entity.User{
+		Username: username,
+		Name:     name,
+		Password: hashedPassword,
+		RoleID:   entity.DefaultRoleID,
+		Status:   entity.UserStatusActive,
+	}
+	if identity.Email != "" {
+		email := identity.Email
+		user.Email = &email
+		if p.TrustsEmail() && identity.EmailVerified {
+			now := time.Now()
+			user.EmailVerifiedAt = &now
+		}
+	}
+	if u.users.verification.BlocksLogin(user) {
+		user.Status = entity.UserStatusPending
+	}
+
+	if err := u.userRepo.Create(ctx, user); err != nil {
+		return nil, uniquenessError(err)
+	}
+	if user.EmailVerifiedAt == nil {
+		u.users.sendVerification(user)
+	}
+	return user, nil
+}
+
+// availableUsername derives a username from the provider's preferred
+// username or the email address, adding a random suffix when it is taken.
+func (u *OIDCLoginUseCase) availableUsername(ctx context.Context, identity *infrastructure.OIDCIdentity) (string, error) {
+	base := usernameFrom(identity.PreferredUsername)
+	if len(base) < 3 {
+		local, _, _ := strings.Cut(identity.Email, "@")
+		base = usernameFrom(local)
+	}
+	if len(base) < 3 {
+		base = "user"
+	}
+
+	candidate := base
+	for i := 0; i < usernameAttempts; i++ {
+		exists, err := u.userRepo.ExistsByUsername(ctx, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+		candidate = fmt.Sprintf("%s_%06d", base, rand.IntN(1_000_000))
+	}
+	return "", fmt.Errorf("no free username for %q", base)
+}
+
+// usernameFrom keeps the characters usernames may contain, leaving room
+// for a suffix.
+func usernameFrom(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			b.WriteRune(r)
+		case r == '.', r == '-':
+			b.WriteRune('_')
+		}
+		if b.Len() == 23 {
+			break
+		}
+	}
+	return b.String()
+}
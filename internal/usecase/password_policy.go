@@ -0,0 +1,254 @@
+package usecase
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/aungmyozaw92/go-grpc-starter/config"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/repository"
+)
+
+// Password policy rules, as reported in PasswordViolation.Rule.
+const (
+	PasswordRuleMinLength = "min_length"
+	PasswordRuleMaxLength = "max_length"
+	PasswordRuleUpper     = "uppercase"
+	PasswordRuleLower     = "lowercase"
+	PasswordRuleDigit     = "digit"
+	PasswordRuleSymbol    = "symbol"
+	PasswordRuleUserInfo  = "user_info"
+	PasswordRuleCommon    = "common"
+	PasswordRuleReused    = "reused"
+)
+
+// PasswordViolation is one policy rule a password breaks.
+type PasswordViolation struct {
+	Rule        string
+	Description string
+}
+
+// PasswordPolicyError lists every rule a password breaks, so the user can
+// fix them all at once.
+type PasswordPolicyError struct {
+	Violations []PasswordViolation
+}
+
+func (e *PasswordPolicyError) Error() string {
+	descriptions := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		descriptions[i] = v.Description
+	}
+	return "password policy violated: " + strings.Join(descriptions, "; ")
+}
+
+// IsPasswordPolicyViolation unwraps a PasswordPolicyError from err.
+func IsPasswordPolicyViolation(err error) (*PasswordPolicyError, bool) {
+	var policyErr *PasswordPolicyError
+	ok := errors.As(err, &policyErr)
+	return policyErr, ok
+}
+
+// minUserInfoLength keeps very short usernames from ruling out most passwords.
+const minUserInfoLength = 3
+
+// PasswordPolicy decides whether a password may be set for a user.
+type PasswordPolicy struct {
+	cfg         config.PasswordPolicyConfig
+	blocklist   map[string]struct{}
+	historyRepo repository.PasswordHistoryRepository
+	hasher      PasswordHashing
+}
+
+func NewPasswordPolicy(cfg config.PasswordPolicyConfig, historyRepo repository.PasswordHistoryRepository, hasher PasswordHashing) (*PasswordPolicy, error) {
+	if cfg.MinLength < 1 {
+		return nil, fmt.Errorf("password minimum length must be at least 1, got %d", cfg.MinLength)
+	}
+	if cfg.MaxLength < cfg.MinLength {
+		return nil, fmt.Errorf("password maximum length %d is below the minimum length %d", cfg.MaxLength, cfg.MinLength)
+	}
+
+	blocklist := make(map[string]struct{})
+	if cfg.BlocklistFile != "" {
+		var err error
+		blocklist, err = loadBlocklist(cfg.BlocklistFile)
+		if err != nil {
+			return nil, fmt.Errorf("password blocklist: %w", err)
+		}
+	}
+
+	return &PasswordPolicy{
+		cfg:         cfg,
+		blocklist:   blocklist,
+		historyRepo: historyRepo,
+		hasher:      hasher,
+	}, nil
+}
+
+// Check returns a PasswordPolicyError when password may not be set for user.
+// user.ID is zero for accounts that do not exist yet, which have no history.
+// The cheap rules are checked first; only a password passing all of them is
+// compared with the user's previous passwords.
+func (p *PasswordPolicy) Check(ctx context.Context, user *entity.User, password string) error {
+	violations := p.checkRules(user, password)
+	if len(violations) > 0 {
+		return &PasswordPolicyError{Violations: violations}
+	}
+
+	reused, err := p.reused(ctx, user, password)
+	if err != nil {
+		return err
+	}
+	if reused {
+		description := "Password must differ from your current password"
+		if p.cfg.HistorySize > 1 {
+			description = fmt.Sprintf("Password must differ from your last %d passwords", p.cfg.HistorySize)
+		}
+		return &PasswordPolicyError{Violations: []PasswordViolation{{Rule: PasswordRuleReused, Description: description}}}
+	}
+	return nil
+}
+
+// Remember records the hash a user's password change is about to replace.
+func (p *PasswordPolicy) Remember(userID uint, previousHash string) error {
+	// The current password is checked on the users table
+	keep := p.cfg.HistorySize - 1
+	if keep < 1 || previousHash == "" {
+		return nil
+	}
+	return p.historyRepo.Add(&entity.PasswordHistory{
+		UserID:       userID,
+		PasswordHash: previousHash,
+	}, keep)
+}
+
+func (p *PasswordPolicy) checkRules(user *entity.User, password string) []PasswordViolation {
+	var violations []PasswordViolation
+	add := func(rule, description string) {
+		violations = append(violations, PasswordViolation{Rule: rule, Description: description})
+	}
+
+	length := utf8.RuneCountInString(password)
+	if length < p.cfg.MinLength {
+		add(PasswordRuleMinLength, fmt.Sprintf("Password must be at least %d characters long", p.cfg.MinLength))
+	}
+	if length > p.cfg.MaxLength {
+		add(PasswordRuleMaxLength, fmt.Sprintf("Password must be at most %d characters long", p.cfg.MaxLength))
+	}
+
+	var hasUpper, hasLower, hasDigit, hasSymbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSymbol = true
+		}
+	}
+	if p.cfg.RequireUpper && !hasUpper {
+		add(PasswordRuleUpper, "Password must contain an uppercase letter")
+	}
+	if p.cfg.RequireLower && !hasLower {
+		add(PasswordRuleLower, "Password must contain a lowercase letter")
+	}
+	if p.cfg.RequireDigit && !hasDigit {
+		add(PasswordRuleDigit, "Password must contain a digit")
+	}
+	if p.cfg.RequireSymbol && !hasSymbol {
+		add(PasswordRuleSymbol, "Password must contain a symbol")
+	}
+
+	lowered := strings.ToLower(password)
+	if p.cfg.DisallowUserInfo && containsUserInfo(lowered, user) {
+		add(PasswordRuleUserInfo, "Password must not contain your username or email address")
+	}
+	if _, blocked := p.blocklist[lowered]; blocked {
+		add(PasswordRuleCommon, "Password is too common")
+	}
+	return violations
+}
+
+// reused compares password with the current one and the stored history.
+func (p *PasswordPolicy) reused(ctx context.Context, user *entity.User, password string) (bool, error) {
+	if p.cfg.HistorySize < 1 || user.ID == 0 {
+		return false, nil
+	}
+
+	hashes := []string{user.Password}
+	if p.cfg.HistorySize > 1 {
+		history, err := p.historyRepo.Recent(user.ID, p.cfg.HistorySize-1)
+		if err != nil {
+			return false, err
+		}
+		for _, entry := range history {
+			hashes = append(hashes, entry.PasswordHash)
+		}
+	}
+
+	for _, hash := range hashes {
+		if hash == "" {
+			continue
+		}
+		matched, err := p.hasher.Verify(ctx, hash, password)
+		if errors.Is(err, infrastructure.ErrUnsupportedHash) {
+			continue
+		}
+		if err != nil {
+			return false, err
+		}
+		if matched {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func containsUserInfo(lowered string, user *entity.User) bool {
+	parts := []string{user.Username}
+	if user.Email != nil {
+		local, _, _ := strings.Cut(*user.Email, "@")
+		parts = append(parts, local)
+	}
+	for _, part := range parts {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if utf8.RuneCountInString(part) >= minUserInfoLength && strings.Contains(lowered, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadBlocklist reads one password per line, skipping blank lines and lines
+// starting with "#".
+func loadBlocklist(path string) (map[string]struct{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	blocklist := make(map[string]struct{})
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		blocklist[strings.ToLower(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return blocklist, nil
+}
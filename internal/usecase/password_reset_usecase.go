@@ -0,0 +1,253 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/aungmyozaw92/go-grpc-starter/config"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// PasswordResetUseCase recovers accounts through single-use tokens mailed to
+// the user's verified email address.
+type PasswordResetUseCase struct {
+	userRepo         repository.UserRepository
+	tokenRepo        repository.OneTimeTokenRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	throttleRepo     repository.LoginThrottleRepository
+	jwt              *infrastructure.JWTManager
+	loginGuard       *LoginGuard
+	mailer           infrastructure.Mailer
+	hasher           PasswordHashing
+	policy           *PasswordPolicy
+	apiKeys          *APIKeyUseCase
+	cfg              config.PasswordResetConfig
+	mails            chan *entity.User
+}
+
+// NewPasswordResetUseCase starts cfg.MailWorkers goroutines sending the reset
+// mail. Request counts are kept in the login throttle store, under keys of
+// their own.
+func NewPasswordResetUseCase(userRepo repository.UserRepository, tokenRepo repository.OneTimeTokenRepository, refreshTokenRepo repository.RefreshTokenRepository, throttleRepo repository.LoginThrottleRepository, jwt *infrastructure.JWTManager, loginGuard *LoginGuard, mailer infrastructure.Mailer, hasher PasswordHashing, policy *PasswordPolicy, apiKeys *APIKeyUseCase, cfg config.PasswordResetConfig) (*PasswordResetUseCase, error) {
+	if cfg.MailWorkers < 1 {
+		return nil, fmt.Errorf("password reset mail workers must be at least 1, got %d", cfg.MailWorkers)
+	}
+	if cfg.MailQueueSize < 0 {
+		return nil, fmt.Errorf("password reset mail queue size must not be negative, got %d", cfg.MailQueueSize)
+	}
+	if cfg.AddressLimit < 1 || cfg.IPLimit < 1 || cfg.RateWindow <= 0 {
+		return nil, errors.New("password reset rate limits and window must be positive")
+	}
+
+	u := &PasswordResetUseCase{
+		userRepo:         userRepo,
+		tokenRepo:        tokenRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		throttleRepo:     throttleRepo,
+		jwt:              jwt,
+		loginGuard:       loginGuard,
+		mailer:           mailer,
+		hasher:           hasher,
+		policy:           policy,
+		apiKeys:          apiKeys,
+		cfg:              cfg,
+		mails:            make(chan *entity.User, cfg.MailQueueSize),
+	}
+	for i := 0; i < cfg.MailWorkers; i++ {
+		go u.sendResetMails()
+	}
+	return u, nil
+}
+
+const (
+	resetEmailThrottlePrefix = "reset-email:"
+	resetIPThrottlePrefix    = "reset-ip:"
+)
+
+// RequestPasswordReset mails a reset token to the account registered with
+// email, provided the address is verified: an unverified one may have been
+// set by anyone allowed to edit the account. Unknown addresses are not an
+// error, so callers cannot tell whether an account exists. For the same
+// reason everything after the lookup happens in the background: storing the
+// token and sending the mail would otherwise make known addresses measurably
+// slower to answer. Requests over the limit for the address or the client's
+// IP return ErrTooManyResetRequests, whether or not the address is known.
+func (u *PasswordResetUseCase) RequestPasswordReset(ctx context.Context, email string, client ClientInfo) error {
+	if err := u.countRequest(email, client.IP); err != nil {
+		return err
+	}
+
+	user, err := u.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if user.EmailVerifiedAt == nil {
+		return nil
+	}
+
+	select {
+	case u.mails <- user:
+	default:
+		log.Printf("Dropped password reset mail to user %d: mail queue is full", user.ID)
+	}
+	return nil
+}
+
+// countRequest counts a reset request against the address and the client IP
+// and returns ErrTooManyResetRequests once either is over its limit.
+func (u *PasswordResetUseCase) countRequest(email, clientIP string) error {
+	now := time.Now()
+	throttle, err := u.throttleRepo.RecordFailure(resetEmailThrottlePrefix+entity.NormalizeEmail(email), now, u.cfg.RateWindow)
+	if err != nil {
+		return err
+	}
+	if throttle.FailedCount > u.cfg.AddressLimit {
+		return ErrTooManyResetRequests
+	}
+	if clientIP == "" {
+		return nil
+	}
+	throttle, err = u.throttleRepo.RecordFailure(resetIPThrottlePrefix+clientIP, now, u.cfg.RateWindow)
+	if err != nil {
+		return err
+	}
+	if throttle.FailedCount > u.cfg.IPLimit {
+		return ErrTooManyResetRequests
+	}
+	return nil
+}
+
+// ResetPassword sets a new password with a token from RequestPasswordReset.
+// The token is consumed, and every existing session of the user is revoked.
+// Login throttling is lifted for the user and for the client resetting.
+func (u *PasswordResetUseCase) ResetPassword(ctx context.Context, token, newPassword string, client ClientInfo) error {
+	stored, err := u.tokenRepo.FindByTokenHash(entity.TokenPurposePasswordReset, infrastructure.HashToken(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+	if stored.UsedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	user, err := u.userRepo.FindByID(ctx, int(stored.UserID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+
+	// Check and hash first so a rejected password does not burn the token
+	if err := u.policy.Check(ctx, user, newPassword); err != nil {
+		return err
+	}
+	hashedPassword, err := u.hasher.Hash(ctx, newPassword)
+	if err != nil {
+		return err
+	}
+
+	// Lost the race against a concurrent use of the same token
+	marked, err := u.tokenRepo.MarkUsed(stored.ID)
+	if err != nil {
+		return err
+	}
+	if !marked {
+		return ErrInvalidResetToken
+	}
+
+	if err := u.policy.Remember(user.ID, user.Password); err != nil {
+		return err
+	}
+	if err := u.userRepo.SetPassword(ctx, user.ID, hashedPassword, infrastructure.RevocationCutoff(), false); err != nil {
+		return err
+	}
+
+	// Whoever knew the old password must not stay signed in
+	if err := u.refreshTokenRepo.RevokeAllForUser(user.ID); err != nil {
+		return err
+	}
+	if err := u.jwt.RevokeAllForUser(user.ID); err != nil {
+		return err
+	}
+	if err := u.apiKeys.revokeAllForUser(user.ID); err != nil {
+		return err
+	}
+	return u.loginGuard.Unlock(user.Username, client.IP)
+}
+
+func (u *PasswordResetUseCase) sendResetMails() {
+	for user := range u.mails {
+		if err := u.sendResetMail(user); err != nil {
+			log.Printf("Failed to send password reset mail to user %d: %v", user.ID, err)
+		}
+	}
+}
+
+func (u *PasswordResetUseCase) sendResetMail(user *entity.User) error {
+	// Only the most recent link stays usable
+	if err := u.tokenRepo.InvalidateForUser(user.ID, entity.TokenPurposePasswordReset); err != nil {
+		return err
+	}
+
+	token, err := infrastructure.GenerateOpaqueToken(32)
+	if err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(u.cfg.TokenTTL)
+	if err := u.tokenRepo.Create(&entity.OneTimeToken{
+		UserID:    user.ID,
+		Purpose:   entity.TokenPurposePasswordReset,
+		TokenHash: infrastructure.HashToken(token),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return err
+	}
+
+	return u.mailer.Send(infrastructure.MailMessage{
+		To:      *user.Email,
+		Subject: "Reset your password",
+		Body:    u.resetMailBody(user, token, expiresAt),
+	})
+}
+
+func (u *PasswordResetUseCase) resetMailBody(user *entity.User, token string, expiresAt time.Time) string {
+	return fmt.Sprintf(`Hello %s,
+
+We received a request to reset the password of your account %q.
+Use the following to choose a new password:
+
+%s
+
+This expires at %s and can be used only once. If you did not ask for a
+password reset, you can ignore this message.
+`, user.Name, user.Username, tokenLink(u.cfg.URL, token), expiresAt.UTC().Format(time.RFC1123))
+}
+
+// tokenLink appends token to baseURL as the "token" query parameter. Without
+// a usable base URL the bare token is returned for the user to paste.
+func tokenLink(baseURL, token string) string {
+	if baseURL == "" {
+		return token
+	}
+	link, err := url.Parse(baseURL)
+	if err != nil {
+		return token
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String()
+}
@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/repository"
+	"gorm.io/gorm"
+)
+
+type RoleUseCase struct {
+	roleRepo repository.RoleRepository
+	userRepo repository.UserRepository
+}
+
+func NewRoleUseCase(roleRepo repository.RoleRepository, userRepo repository.UserRepository) *RoleUseCase {
+	return &RoleUseCase{roleRepo: roleRepo, userRepo: userRepo}
+}
+
+// EnsureDefaults seeds the permission catalog and the built-in "user" and
+// "admin" roles, and keeps the admin role in sync with the catalog.
+func (r *RoleUseCase) EnsureDefaults() error {
+	catalog := make([]entity.Permission, len(entity.AllPermissions))
+	copy(catalog, entity.AllPermissions)
+	if err := r.roleRepo.EnsurePermissions(catalog); err != nil {
+		return err
+	}
+
+	if _, err := r.roleRepo.FindByID(entity.DefaultRoleID); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err := r.roleRepo.Create(&entity.Role{
+			ID:          entity.DefaultRoleID,
+			Name:        entity.RoleNameUser,
+			Description: "Regular user; may view and edit only their own account",
+		}); err != nil {
+			return err
+		}
+	}
+
+	admin, err := r.roleRepo.FindByName(entity.RoleNameAdmin)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		admin = &entity.Role{
+			Name:        entity.RoleNameAdmin,
+			Description: "Administrator with every permission",
+		}
+		if err := r.roleRepo.Create(admin); err != nil {
+			return err
+		}
+	}
+
+	names := make([]string, 0, len(entity.AllPermissions))
+	for _, permission := range entity.AllPermissions {
+		names = append(names, permission.Name)
+	}
+	admin.Permissions, err = r.roleRepo.FindPermissionsByNames(names)
+	if err != nil {
+		return err
+	}
+	return r.roleRepo.Update(admin)
+}
+
+// BootstrapAdmin grants the admin role to an existing user. It is how the
+// first administrator is created on a fresh deployment, after registering
+// or UserUseCase.CreateBootstrapUser.
+func (r *RoleUseCase) BootstrapAdmin(ctx context.Context, username string) error {
+	user, err := r.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("Bootstrap admin %q does not exist yet; register it and restart", username)
+			return nil
+		}
+		return err
+	}
+	admin, err := r.roleRepo.FindByName(entity.RoleNameAdmin)
+	if err != nil {
+		return err
+	}
+	if user.RoleID == int(admin.ID) {
+		return nil
+	}
+	user.RoleID = int(admin.ID)
+	return r.userRepo.Update(ctx, user)
+}
+
+func (r *RoleUseCase) ListRoles(ctx context.Context) ([]*entity.Role, error) {
+	if _, err := requirePermission(ctx, entity.PermissionRolesManage); err != nil {
+		return nil, err
+	}
+	return r.roleRepo.List()
+}
+
+func (r *RoleUseCase) ListPermissions(ctx context.Context) ([]*entity.Permission, error) {
+	if _, err := requirePermission(ctx, entity.PermissionRolesManage); err != nil {
+		return nil, err
+	}
+	return r.roleRepo.ListPermissions()
+}
+
+func (r *RoleUseCase) CreateRole(ctx context.Context, role *entity.Role, permissionNames []string) (*entity.Role, error) {
+	if _, err := requirePermission(ctx, entity.PermissionRolesManage); err != nil {
+		return nil, err
+	}
+
+	exists, err := r.roleRepo.ExistsByName(role.Name)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrRoleExists
+	}
+
+	role.Permissions, err = resolvePermissions(r.roleRepo, permissionNames)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.roleRepo.Create(role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (r *RoleUseCase) UpdateRole(ctx context.Context, roleID uint, updateData *entity.Role, permissionNames []string) (*entity.Role, error) {
+	if _, err := requirePermission(ctx, entity.PermissionRolesManage); err != nil {
+		return nil, err
+	}
+
+	role, err := r.findRole(roleID)
+	if err != nil {
+		return nil, err
+	}
+	if isBuiltInRole(role) {
+		return nil, ErrBuiltInRole
+	}
+
+	if updateData.Name != role.Name {
+		exists, err := r.roleRepo.ExistsByNameExcludeID(updateData.Name, role.ID)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, ErrRoleExists
+		}
+	}
+
+	role.Permissions, err = resolvePermissions(r.roleRepo, permissionNames)
+	if err != nil {
+		return nil, err
+	}
+	role.Name = updateData.Name
+	role.Description = updateData.Description
+
+	if err := r.roleRepo.Update(role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+func (r *RoleUseCase) DeleteRole(ctx context.Context, roleID uint) error {
+	if _, err := requirePermission(ctx, entity.PermissionRolesManage); err != nil {
+		return err
+	}
+
+	role, err := r.findRole(roleID)
+	if err != nil {
+		return err
+	}
+	if isBuiltInRole(role) {
+		return ErrBuiltInRole
+	}
+
+	count, err := r.userRepo.CountByRole(ctx, role.ID)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrRoleInUse
+	}
+
+	return r.roleRepo.Delete(role.ID)
+}
+
+func (r *RoleUseCase) AssignRole(ctx context.Context, userID int, roleID uint) (*entity.User, error) {
+	if _, err := requirePermission(ctx, entity.PermissionRolesAssign); err != nil {
+		return nil, err
+	}
+
+	user, err := r.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.findRole(roleID); err != nil {
+		return nil, err
+	}
+
+	user.RoleID = int(roleID)
+	if err := r.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *RoleUseCase) findRole(roleID uint) (*entity.Role, error) {
+	role, err := r.roleRepo.FindByID(roleID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, err
+	}
+	return role, nil
+}
+
+// resolvePermissions looks up catalog permissions by name, failing with
+// ErrUnknownPermission when any of them does not exist.
+func resolvePermissions(roleRepo repository.RoleRepository, names []string) ([]entity.Permission, error) {
+	permissions, err := roleRepo.FindPermissionsByNames(names)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]bool, len(permissions))
+	for _, permission := range permissions {
+		found[permission.Name] = true
+	}
+	for _, name := range names {
+		if !found[name] {
+			return nil, ErrUnknownPermission
+		}
+	}
+	return permissions, nil
+}
+
+func isBuiltInRole(role *entity.Role) bool {
+	return role.Name == entity.RoleNameUser || role.Name == entity.RoleNameAdmin
+}
@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
+	"github.com/aungmyozaw92/go-grpc-starter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// maxUserAgentLength matches the size of Session.UserAgent.
+const maxUserAgentLength = 255
+
+// ClientInfo describes where a request came from. Either field may be empty
+// when unknown.
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+}
+
+type SessionUseCase struct {
+	sessionRepo      repository.SessionRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+}
+
+func NewSessionUseCase(sessionRepo repository.SessionRepository, refreshTokenRepo repository.RefreshTokenRepository) *SessionUseCase {
+	return &SessionUseCase{sessionRepo: sessionRepo, refreshTokenRepo: refreshTokenRepo}
+}
+
+// ListSessions returns the caller's active sessions, most recently used
+// first.
+func (s *SessionUseCase) ListSessions(ctx context.Context) ([]*entity.Session, error) {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.sessionRepo.ListActive(principal.UserID)
+}
+
+// RevokeSession signs the caller out on one device. Sessions of other users
+// are reported as not found.
+func (s *SessionUseCase) RevokeSession(ctx context.Context, sessionID uint) error {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return err
+	}
+	session, err := s.sessionRepo.FindActiveByID(sessionID)
+	if err != nil {
+		return err
+	}
+	if session.UserID != principal.UserID {
+		return gorm.ErrRecordNotFound
+	}
+	return s.refreshTokenRepo.RevokeFamily(session.FamilyID)
+}
+
+// ListUserSessions returns the active sessions of any user.
+func (s *SessionUseCase) ListUserSessions(ctx context.Context, userID uint) ([]*entity.Session, error) {
+	if _, err := requirePermission(ctx, entity.PermissionSessionsManage); err != nil {
+		return nil, err
+	}
+	return s.sessionRepo.ListActive(userID)
+}
+
+// RevokeUserSession ends a session of any user.
+func (s *SessionUseCase) RevokeUserSession(ctx context.Context, sessionID uint) error {
+	if _, err := requirePermission(ctx, entity.PermissionSessionsManage); err != nil {
+		return err
+	}
+	session, err := s.sessionRepo.FindActiveByID(sessionID)
+	if err != nil {
+		return err
+	}
+	return s.refreshTokenRepo.RevokeFamily(session.FamilyID)
+}
+
+// start records a new session for client and returns the refresh token
+// family to issue its tokens in.
+func (s *SessionUseCase) start(userID uint, client ClientInfo) (string, error) {
+	return s.create(&entity.Session{UserID: userID}, client)
+}
+
+// startOAuth records a session of an OAuth client, see start.
+func (s *SessionUseCase) startOAuth(userID uint, client ClientInfo, clientID, scope string) (string, error) {
+	return s.create(&entity.Session{UserID: userID, ClientID: clientID, Scope: scope}, client)
+}
+
+func (s *SessionUseCase) create(session *entity.Session, client ClientInfo) (string, error) {
+	familyID, err := infrastructure.GenerateOpaqueToken(16)
+	if err != nil {
+		return "", err
+	}
+	session.FamilyID = familyID
+	session.UserAgent = truncateRunes(client.UserAgent, maxUserAgentLength)
+	session.IPAddress = client.IP
+	session.LastUsedAt = time.Now()
+	if err := s.sessionRepo.Create(session); err != nil {
+		return "", err
+	}
+	return familyID, nil
+}
+
+// find returns the active session of a refresh token family. Families
+// issued before sessions existed have none.
+func (s *SessionUseCase) find(familyID string) (*entity.Session, error) {
+	return s.sessionRepo.FindActiveByFamilyID(familyID)
+}
+
+// check rejects access tokens of a session that has ended, and records the
+// use of the session at lastUsedResolution.
+func (s *SessionUseCase) check(familyID string) (*entity.Session, error) {
+	session, err := s.find(familyID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, infrastructure.ErrRevokedToken
+		}
+		return nil, err
+	}
+	if now := time.Now(); now.Sub(session.LastUsedAt) >= lastUsedResolution {
+		// Only bookkeeping, the request goes ahead either way
+		if err := s.sessionRepo.MarkUsed(familyID, now); err != nil {
+			log.Printf("Failed to record use of session %d: %v", session.ID, err)
+		}
+	}
+	return session, nil
+}
+
+// refreshed records that the session's tokens were refreshed.
+func (s *SessionUseCase) refreshed(familyID string) error {
+	return s.sessionRepo.MarkUsed(familyID, time.Now())
+}
+
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
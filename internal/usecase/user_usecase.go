@@ -1,73 +1,739 @@
 package usecase
 
 import (
+	"context"
 	"errors"
+	"log"
 	"math"
+	"strings"
+	"time"
 
 	"github.com/aungmyozaw92/go-grpc-starter/internal/entity"
 	"github.com/aungmyozaw92/go-grpc-starter/internal/infrastructure"
 	"github.com/aungmyozaw92/go-grpc-starter/internal/repository"
+	"gorm.io/gorm"
 )
 
+// PasswordHashing hashes and verifies passwords, such as
+// infrastructure.HashingPool does on its workers.
+type PasswordHashing interface {
+	Hash(ctx context.Context, password string) (string, error)
+	Verify(ctx context.Context, encodedHash, password string) (bool, error)
+	NeedsRehash(encodedHash string) bool
+}
+
 type UserUseCase struct {
-	userRepo repository.UserRepository
+	userRepo         repository.UserRepository
+	roleRepo         repository.RoleRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	jwt              *infrastructure.JWTManager
+	refreshTokenTTL  time.Duration
+	loginGuard       *LoginGuard
+	verification     *EmailVerificationUseCase
+	mfa              *MFAUseCase
+	hasher           PasswordHashing
+	policy           *PasswordPolicy
+	apiKeys          *APIKeyUseCase
+	sessions         *SessionUseCase
+}
+
+func NewUserUseCase(userRepo repository.UserRepository, roleRepo repository.RoleRepository, refreshTokenRepo repository.RefreshTokenRepository, jwt *infrastructure.JWTManager, refreshTokenTTL time.Duration, loginGuard *LoginGuard, verification *EmailVerificationUseCase, mfa *MFAUseCase, hasher PasswordHashing, policy *PasswordPolicy, apiKeys *APIKeyUseCase, sessions *SessionUseCase) *UserUseCase {
+	return &UserUseCase{
+		userRepo:         userRepo,
+		roleRepo:         roleRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		jwt:              jwt,
+		refreshTokenTTL:  refreshTokenTTL,
+		loginGuard:       loginGuard,
+		verification:     verification,
+		mfa:              mfa,
+		hasher:           hasher,
+		policy:           policy,
+		apiKeys:          apiKeys,
+		sessions:         sessions,
+	}
 }
 
-func NewUserUseCase(userRepo repository.UserRepository) *UserUseCase {
-	return &UserUseCase{userRepo: userRepo}
+// AuthTokens is the access/refresh token pair handed out after authentication.
+type AuthTokens struct {
+	AccessToken           string
+	AccessTokenExpiresAt  time.Time
+	RefreshToken          string
+	RefreshTokenExpiresAt time.Time
+	// PasswordChangeRequired tells the client to call ChangePassword first.
+	PasswordChangeRequired bool
 }
 
-func (u *UserUseCase) Register(user *entity.User) (string, error) {
-	// Check if username already exists
-	exists, err := u.userRepo.ExistsByUsername(user.Username)
+// Register creates a self-service account and mails a verification token to
+// its email address. When unverified accounts may not sign in no tokens are
+// issued and the returned AuthTokens are nil.
+func (u *UserUseCase) Register(ctx context.Context, user *entity.User, client ClientInfo) (*AuthTokens, error) {
+	// Self-registered accounts always start with the default role
+	user.RoleID = entity.DefaultRoleID
+
+	// Check if username already exists. Saves hashing the password in the
+	// common case; concurrent requests are still caught by uniquenessError.
+	exists, err := u.userRepo.ExistsByUsername(ctx, user.Username)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	if exists {
-		return "", errors.New("username already exists")
+		return nil, ErrUsernameExists
 	}
 
 	// Check if email already exists (if email is provided)
 	if user.Email != nil && *user.Email != "" {
-		emailExists, err := u.userRepo.ExistsByEmail(*user.Email)
+		emailExists, err := u.userRepo.ExistsByEmail(ctx, *user.Email)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 		if emailExists {
-			return "", errors.New("email already exists")
+			return nil, ErrEmailExists
 		}
 	}
 
-	hashedPassword, err := infrastructure.HashPassword(user.Password)
+	if err := u.policy.Check(ctx, user, user.Password); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := u.hasher.Hash(ctx, user.Password)
+	if err != nil {
+		return nil, err
+	}
+	user.Password = hashedPassword
+
+	// The account is activated by verifying the email address
+	user.Status = entity.UserStatusActive
+	if u.verification.BlocksLogin(user) {
+		user.Status = entity.UserStatusPending
+	}
+
+	if err := u.userRepo.Create(ctx, user); err != nil {
+		return nil, uniquenessError(err)
+	}
+	u.sendVerification(user)
+
+	if user.Status == entity.UserStatusPending {
+		return nil, nil
+	}
+	return u.startSession(user, client)
+}
+
+// Login checks the credentials of identifier, a username or an email
+// address. The client's IP address is used together with the account to
+// throttle repeated failures, and recorded with the new session. Users with
+// two-factor authentication get an MFAChallenge for VerifyMFA instead of
+// tokens.
+func (u *UserUseCase) Login(ctx context.Context, identifier, password string, client ClientInfo) (*AuthTokens, *MFAChallenge, error) {
+	user, err := u.findByIdentifier(ctx, identifier)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, err
+	}
+	// Failures count against the account however it was named, so varying
+	// the case or signing in by email buys no extra attempts
+	username := entity.NormalizeUsername(identifier)
+	if err == nil {
+		username = user.Username
+	}
+
+	// Refuse early, before paying for a password hash comparison
+	if err := u.loginGuard.Reserve(username, client.IP); err != nil {
+		return nil, nil, err
+	}
+
+	matched := false
+	if err == nil {
+		matched, err = u.hasher.Verify(ctx, user.Password, password)
+		if err != nil {
+			u.releaseAttempt(username, client.IP)
+			return nil, nil, err
+		}
+	}
+	if !matched {
+		if err := u.loginGuard.RecordFailure(username, client.IP); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, ErrInvalidCredentials
+	}
+	if err := u.loginGuard.Release(username, client.IP); err != nil {
+		return nil, nil, err
+	}
+	u.rehashPassword(ctx, user, password)
+
+	// Only reported after the password matched, so it reveals nothing. A
+	// fresh link is mailed, the earlier one may have expired or been lost.
+	if u.verification.BlocksLogin(user) {
+		u.sendVerification(user)
+		return nil, nil, ErrEmailNotVerified
+	}
+	if err := checkAccountStatus(user, time.Now()); err != nil {
+		return nil, nil, err
+	}
+
+	// Failure counters are kept until the second factor is verified too, or
+	// a known password would buy unlimited guesses at the code
+	mfaEnabled, err := u.mfa.Enabled(user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if mfaEnabled {
+		challenge, err := u.mfa.createChallenge(user.ID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return nil, challenge, nil
+	}
+
+	if err := u.loginGuard.RecordSuccess(username); err != nil {
+		return nil, nil, err
+	}
+	tokens, err := u.startSession(user, client)
+	return tokens, nil, err
+}
+
+// findByIdentifier looks up a user by email address when identifier has an
+// "@", and by username otherwise. Only self-registered usernames are known
+// not to contain one, so an email miss falls back to usernames.
+func (u *UserUseCase) findByIdentifier(ctx context.Context, identifier string) (*entity.User, error) {
+	if strings.Contains(identifier, "@") {
+		user, err := u.userRepo.FindByEmail(ctx, identifier)
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return user, err
+		}
+	}
+	return u.userRepo.FindByUsername(ctx, identifier)
+}
+
+// VerifyMFA completes a login that returned an MFAChallenge. code is a TOTP
+// code or a recovery code; wrong codes count as failed logins.
+func (u *UserUseCase) VerifyMFA(ctx context.Context, mfaToken, code string, client ClientInfo) (*AuthTokens, error) {
+	challenge, err := u.mfa.findChallenge(mfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.FindByID(ctx, int(challenge.UserID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidMFAToken
+		}
+		return nil, err
+	}
+
+	if err := checkAccountStatus(user, time.Now()); err != nil {
+		return nil, err
+	}
+	if err := u.loginGuard.Reserve(user.Username, client.IP); err != nil {
+		return nil, err
+	}
+	ok, err := u.mfa.verifyCode(user.ID, code)
+	if err != nil {
+		u.releaseAttempt(user.Username, client.IP)
+		return nil, err
+	}
+	if !ok {
+		if err := u.loginGuard.RecordFailure(user.Username, client.IP); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidMFACode
+	}
+	if err := u.loginGuard.Release(user.Username, client.IP); err != nil {
+		return nil, err
+	}
+
+	if err := u.mfa.consumeChallenge(challenge); err != nil {
+		return nil, err
+	}
+	if err := u.loginGuard.RecordSuccess(user.Username); err != nil {
+		return nil, err
+	}
+	return u.startSession(user, client)
+}
+
+// UnlockUser clears login backoff and lockout for a user, and for the client
+// IP address the user signs in from when clientIP is set.
+func (u *UserUseCase) UnlockUser(ctx context.Context, userID int, clientIP string) error {
+	if _, err := requirePermission(ctx, entity.PermissionUsersUnlock); err != nil {
+		return err
+	}
+
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	return u.loginGuard.Unlock(user.Username, clientIP)
+}
+
+// SuspendUser takes an account out of service until ReactivateUser or, when
+// expiresAt is set, until then. lock marks it locked for security reasons
+// instead of suspended. All sessions of the user end either way.
+func (u *UserUseCase) SuspendUser(ctx context.Context, userID int, reason string, expiresAt *time.Time, lock bool) (*entity.User, error) {
+	principal, err := requirePermission(ctx, entity.PermissionUsersSuspend)
+	if err != nil {
+		return nil, err
+	}
+	if uint(userID) == principal.UserID {
+		return nil, ErrSuspendSelf
+	}
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, ErrInvalidStatusExpiry
+	}
+
+	user, err := u.userRepo.FindByID(ctx, userID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	user.Password = string(hashedPassword)
 
-	if err := u.userRepo.Create(user); err != nil {
-		return "", err
+	status := entity.UserStatusSuspended
+	if lock {
+		status = entity.UserStatusLocked
+	}
+	if err := u.userRepo.UpdateStatus(ctx, user.ID, status, reason, expiresAt); err != nil {
+		return nil, err
 	}
+	user.Status, user.StatusReason, user.StatusExpiresAt = status, reason, expiresAt
 
-	return infrastructure.GenerateJWT(int(user.ID))
+	// Sessions must not come back to life on reactivation
+	if err := u.refreshTokenRepo.RevokeAllForUser(user.ID); err != nil {
+		return nil, err
+	}
+	if err := u.jwt.RevokeAllForUser(user.ID); err != nil {
+		return nil, err
+	}
+	return user, nil
 }
 
-func (u *UserUseCase) Login(username, password string) (string, error) {
-	user, err := u.userRepo.FindByUsername(username)
+// ReactivateUser makes a pending, suspended or locked account active again.
+func (u *UserUseCase) ReactivateUser(ctx context.Context, userID int, reason string) (*entity.User, error) {
+	if _, err := requirePermission(ctx, entity.PermissionUsersSuspend); err != nil {
+		return nil, err
+	}
+
+	user, err := u.userRepo.FindByID(ctx, userID)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	if !infrastructure.CheckPasswordHash(user.Password, password) {
-		return "", errors.New("invalid credentials")
+	if user.Status == entity.UserStatusActive {
+		return user, nil
 	}
-	return infrastructure.GenerateJWT(int(user.ID))
+
+	if err := u.userRepo.UpdateStatus(ctx, user.ID, entity.UserStatusActive, reason, nil); err != nil {
+		return nil, err
+	}
+	user.Status, user.StatusReason, user.StatusExpiresAt = entity.UserStatusActive, reason, nil
+	return user, nil
 }
 
-func (u *UserUseCase) GetProfile(token string) (*entity.User, error) {
-	userID, err := infrastructure.ValidateToken(token)
+// RefreshToken exchanges a refresh token for a new token pair, see
+// redeemRefreshToken.
+func (u *UserUseCase) RefreshToken(ctx context.Context, refreshToken string) (*AuthTokens, error) {
+	var user *entity.User
+	stored, err := redeemRefreshToken(u.refreshTokenRepo, refreshToken, func(stored *entity.RefreshToken) error {
+		// Tokens of OAuth clients are refreshed through the OAuth token endpoint
+		session, err := u.sessions.find(stored.FamilyID)
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err == nil && session.ClientID != "" {
+			return ErrInvalidRefreshToken
+		}
+		// Looked up before the token is used up, so that a canceled request
+		// leaves it valid
+		user, err = u.userRepo.FindByID(ctx, int(stored.UserID))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrInvalidRefreshToken
+		}
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
-	return u.userRepo.FindByID(userID)
+
+	if u.verification.BlocksLogin(user) {
+		return nil, ErrEmailNotVerified
+	}
+	if err := checkAccountStatus(user, time.Now()); err != nil {
+		return nil, err
+	}
+
+	tokens, err := u.issueTokens(user, stored.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.sessions.refreshed(stored.FamilyID); err != nil {
+		log.Printf("Failed to record refresh of session: %v", err)
+	}
+	return tokens, nil
+}
+
+// Authenticate validates an access token or API key and returns the
+// principal it identifies, including the permissions of the user's current
+// role.
+func (u *UserUseCase) Authenticate(ctx context.Context, token string) (*Principal, error) {
+	if infrastructure.IsAPIKey(token) {
+		return u.apiKeys.Authenticate(ctx, token)
+	}
+
+	claims, err := u.jwt.ParseToken(token)
+	if err != nil {
+		return nil, err
+	}
+	// Tokens of OAuth clients are limited to the OAuth endpoints
+	if claims.ClientID != "" {
+		return nil, infrastructure.ErrInvalidToken
+	}
+	user, err := tokenUser(ctx, u.userRepo, u.sessions, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := grantedPermissions(u.roleRepo, u.verification, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Principal{
+		UserID:      user.ID,
+		TokenID:     claims.ID,
+		SessionID:   claims.SessionID,
+		ExpiresAt:   claims.ExpiresAt.Time,
+		RoleID:      uint(user.RoleID),
+		Permissions: permissions,
+	}, nil
+}
+
+// tokenUser returns the user of a parsed access token, as long as the
+// account is active and the token's session has not ended.
+func tokenUser(ctx context.Context, userRepo repository.UserRepository, sessions *SessionUseCase, claims *infrastructure.JWTClaim) (*entity.User, error) {
+	user, err := userRepo.FindByID(ctx, claims.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, infrastructure.ErrInvalidToken
+		}
+		return nil, err
+	}
+
+	if err := checkAccountStatus(user, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// Sessions from before a password change end with it. The change time is
+	// a revocation cutoff, see infrastructure.RevocationCutoff.
+	if user.PasswordChangedAt != nil && !claims.IssuedAt.Time.After(*user.PasswordChangedAt) {
+		return nil, infrastructure.ErrRevokedToken
+	}
+	if claims.SessionID != "" {
+		if _, err := sessions.check(claims.SessionID); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// Logout revokes the caller's access token and, when given, the refresh
+// token family it was issued with.
+func (u *UserUseCase) Logout(ctx context.Context, refreshToken string) error {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return err
+	}
+	if err := u.jwt.RevokeToken(principal.TokenID, principal.UserID, principal.ExpiresAt); err != nil {
+		return err
+	}
+
+	if refreshToken == "" {
+		return nil
+	}
+	stored, err := u.refreshTokenRepo.FindByTokenHash(infrastructure.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	if stored.UserID != principal.UserID {
+		return nil
+	}
+	return u.refreshTokenRepo.RevokeFamily(stored.FamilyID)
+}
+
+// RevokeAllSessions signs the user out everywhere: every access token issued
+// so far and every refresh token are revoked.
+func (u *UserUseCase) RevokeAllSessions(ctx context.Context) error {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return err
+	}
+	if err := u.refreshTokenRepo.RevokeAllForUser(principal.UserID); err != nil {
+		return err
+	}
+	return u.jwt.RevokeAllForUser(principal.UserID)
+}
+
+// ChangePassword replaces the caller's password after checking the current
+// one, which counts as a login attempt for throttling. All sessions of the
+// user are ended, and the caller gets fresh tokens to continue with.
+func (u *UserUseCase) ChangePassword(ctx context.Context, currentPassword, newPassword string, client ClientInfo) (*AuthTokens, error) {
+	principal, err := requireSession(ctx)
+	if err != nil {
+		return nil, err
+	}
+	user, err := u.userRepo.FindByID(ctx, int(principal.UserID))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.loginGuard.Reserve(user.Username, client.IP); err != nil {
+		return nil, err
+	}
+	matched, err := u.hasher.Verify(ctx, user.Password, currentPassword)
+	if err != nil {
+		u.releaseAttempt(user.Username, client.IP)
+		return nil, err
+	}
+	if !matched {
+		if err := u.loginGuard.RecordFailure(user.Username, client.IP); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidCurrentPassword
+	}
+	if err := u.loginGuard.Release(user.Username, client.IP); err != nil {
+		return nil, err
+	}
+
+	if err := u.setPassword(ctx, user, newPassword, false); err != nil {
+		return nil, err
+	}
+	if err := u.loginGuard.RecordSuccess(user.Username); err != nil {
+		return nil, err
+	}
+	user.PasswordChangeRequired = false
+	return u.startSession(user, client)
+}
+
+// SetPassword lets an administrator replace a user's password, optionally
+// forcing the user to choose another one at the next sign-in. All sessions
+// of the user are ended.
+func (u *UserUseCase) SetPassword(ctx context.Context, userID int, newPassword string, requireChange bool) error {
+	principal, err := requirePermission(ctx, entity.PermissionUsersSetPassword)
+	if err != nil {
+		return err
+	}
+	user, err := u.userRepo.FindByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	// Whoever sets the password can sign in as the user, which for anything
+	// but the default role is as good as assigning the role to oneself
+	if user.RoleID != entity.DefaultRoleID {
+		if err := checkRoleAssignment(u.roleRepo, principal, uint(user.RoleID)); err != nil {
+			return err
+		}
+	}
+
+	if err := u.setPassword(ctx, user, newPassword, requireChange); err != nil {
+		return err
+	}
+	return u.loginGuard.Unlock(user.Username, "")
+}
+
+// setPassword checks newPassword against the policy, stores it and ends
+// every session and revokes every API key of the user.
+func (u *UserUseCase) setPassword(ctx context.Context, user *entity.User, newPassword string, requireChange bool) error {
+	if err := u.policy.Check(ctx, user, newPassword); err != nil {
+		return err
+	}
+	hashedPassword, err := u.hasher.Hash(ctx, newPassword)
+	if err != nil {
+		return err
+	}
+
+	if err := u.policy.Remember(user.ID, user.Password); err != nil {
+		return err
+	}
+	if err := u.userRepo.SetPassword(ctx, user.ID, hashedPassword, infrastructure.RevocationCutoff(), requireChange); err != nil {
+		return err
+	}
+
+	// Access tokens are rejected through PasswordChangedAt
+	if err := u.refreshTokenRepo.RevokeAllForUser(user.ID); err != nil {
+		return err
+	}
+	return u.apiKeys.revokeAllForUser(user.ID)
+}
+
+// redeemRefreshToken marks a refresh token used and returns it. Refresh
+// tokens are single-use: presenting one that was already exchanged is
+// treated as theft and revokes every token in its family. accept is checked
+// before the token is used up, so a rejected token can still be redeemed
+// where it belongs.
+func redeemRefreshToken(repo repository.RefreshTokenRepository, refreshToken string, accept func(*entity.RefreshToken) error) (*entity.RefreshToken, error) {
+	stored, err := repo.FindByTokenHash(infrastructure.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+
+	if stored.UsedAt != nil {
+		return nil, revokeReusedFamily(repo, stored.FamilyID)
+	}
+	if stored.RevokedAt != nil || time.Now().After(stored.ExpiresAt) {
+		return nil, ErrInvalidRefreshToken
+	}
+	if err := accept(stored); err != nil {
+		return nil, err
+	}
+
+	// Lost the race against a concurrent exchange of the same token
+	marked, err := repo.MarkUsed(stored.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !marked {
+		return nil, revokeReusedFamily(repo, stored.FamilyID)
+	}
+	return stored, nil
+}
+
+func revokeReusedFamily(repo repository.RefreshTokenRepository, familyID string) error {
+	if err := repo.RevokeFamily(familyID); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// issueRefreshToken persists a new refresh token in a token family.
+func issueRefreshToken(repo repository.RefreshTokenRepository, userID uint, familyID string, ttl time.Duration) (string, time.Time, error) {
+	refreshToken, err := infrastructure.GenerateOpaqueToken(32)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	expiresAt := time.Now().Add(ttl)
+
+	if err := repo.Create(&entity.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: infrastructure.HashToken(refreshToken),
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		return "", time.Time{}, err
+	}
+	return refreshToken, expiresAt, nil
+}
+
+// startSession records a new session for client and issues its first
+// tokens.
+func (u *UserUseCase) startSession(user *entity.User, client ClientInfo) (*AuthTokens, error) {
+	familyID, err := u.sessions.start(user.ID, client)
+	if err != nil {
+		return nil, err
+	}
+	return u.issueTokens(user, familyID)
+}
+
+// issueTokens signs a new access token and persists a new refresh token in
+// the token family of a session.
+func (u *UserUseCase) issueTokens(user *entity.User, familyID string) (*AuthTokens, error) {
+	accessToken, accessExpiresAt, err := u.jwt.GenerateJWT(int(user.ID), familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, refreshExpiresAt, err := issueRefreshToken(u.refreshTokenRepo, user.ID, familyID, u.refreshTokenTTL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthTokens{
+		AccessToken:            accessToken,
+		AccessTokenExpiresAt:   accessExpiresAt,
+		RefreshToken:           refreshToken,
+		RefreshTokenExpiresAt:  refreshExpiresAt,
+		PasswordChangeRequired: user.PasswordChangeRequired,
+	}, nil
+}
+
+// TokenIntrospection describes an access token in the spirit of RFC 7662.
+// Only Active is meaningful when the token is not active.
+type TokenIntrospection struct {
+	Active    bool
+	Subject   string
+	UserID    uint
+	Username  string
+	Roles     []string
+	JTI       string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// IntrospectToken reports whether an access token is currently accepted by
+// this service, by the same checks as Authenticate. Tokens it would reject,
+// such as expired or revoked ones, tokens of OAuth clients, of inactive or
+// deleted users, of ended sessions and from before a password change, are
+// reported as inactive rather than as errors. As in RFC 7662 the caller must
+// be authorized to ask, here by a permission.
+func (u *UserUseCase) IntrospectToken(ctx context.Context, token string) (*TokenIntrospection, error) {
+	if _, err := requirePermission(ctx, entity.PermissionTokensIntrospect); err != nil {
+		return nil, err
+	}
+
+	claims, err := u.jwt.ParseToken(token)
+	if err != nil {
+		if rejectedToken(err) {
+			return &TokenIntrospection{Active: false}, nil
+		}
+		return nil, err
+	}
+	if claims.ClientID != "" {
+		return &TokenIntrospection{Active: false}, nil
+	}
+	user, err := tokenUser(ctx, u.userRepo, u.sessions, claims)
+	if err != nil {
+		if rejectedToken(err) {
+			return &TokenIntrospection{Active: false}, nil
+		}
+		return nil, err
+	}
+
+	var roles []string
+	role, err := u.roleRepo.FindByID(uint(user.RoleID))
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err == nil {
+		roles = append(roles, role.Name)
+	}
+
+	return &TokenIntrospection{
+		Active:    true,
+		Subject:   claims.Subject,
+		UserID:    user.ID,
+		Username:  user.Username,
+		Roles:     roles,
+		JTI:       claims.ID,
+		IssuedAt:  claims.IssuedAt.Time,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
+
+// rejectedToken reports whether err rejects a token, as opposed to failing
+// to check it.
+func rejectedToken(err error) bool {
+	if _, ok := IsAccountInactive(err); ok {
+		return true
+	}
+	return errors.Is(err, infrastructure.ErrInvalidToken) || errors.Is(err, infrastructure.ErrRevokedToken)
+}
+
+func (u *UserUseCase) GetProfile(ctx context.Context) (*entity.User, error) {
+	principal, err := requirePrincipal(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.userRepo.FindByID(ctx, int(principal.UserID))
 }
 
 type UserListResult struct {
@@ -84,9 +750,9 @@ type PaginationInfo struct {
 	HasPrev     bool
 }
 
-func (u *UserUseCase) GetUserList(token string, page, limit int, search string) (*UserListResult, error) {
-	// Validate token
-	_, err := infrastructure.ValidateToken(token)
+func (u *UserUseCase) GetUserList(ctx context.Context, page, limit int, search string) (*UserListResult, error) {
+	// Listing exposes every account
+	_, err := requirePermission(ctx, entity.PermissionUsersRead)
 	if err != nil {
 		return nil, err
 	}
@@ -100,7 +766,7 @@ func (u *UserUseCase) GetUserList(token string, page, limit int, search string)
 	}
 
 	// Get users and total count
-	users, total, err := u.userRepo.GetUserList(page, limit, search)
+	users, total, err := u.userRepo.GetUserList(ctx, page, limit, search)
 	if err != nil {
 		return nil, err
 	}
@@ -125,15 +791,15 @@ func (u *UserUseCase) GetUserList(token string, page, limit int, search string)
 	}, nil
 }
 
-func (u *UserUseCase) GetUser(token string, userID int) (*entity.User, error) {
-	// Validate token
-	_, err := infrastructure.ValidateToken(token)
+func (u *UserUseCase) GetUser(ctx context.Context, userID int) (*entity.User, error) {
+	// Users may always read their own record
+	_, err := requireSelfOrPermission(ctx, uint(userID), entity.PermissionUsersRead)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get user by ID
-	user, err := u.userRepo.FindByID(userID)
+	user, err := u.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -141,88 +807,128 @@ func (u *UserUseCase) GetUser(token string, userID int) (*entity.User, error) {
 	return user, nil
 }
 
-func (u *UserUseCase) CreateUser(token string, user *entity.User) (*entity.User, error) {
-	// Validate token (only authenticated users can create users)
-	_, err := infrastructure.ValidateToken(token)
+func (u *UserUseCase) CreateUser(ctx context.Context, user *entity.User) (*entity.User, error) {
+	principal, err := requirePermission(ctx, entity.PermissionUsersCreate)
 	if err != nil {
 		return nil, err
 	}
 
+	// Creating a user with anything but the default role is a role assignment
+	if user.RoleID == 0 {
+		user.RoleID = entity.DefaultRoleID
+	}
+	if user.RoleID != entity.DefaultRoleID {
+		if err := checkRoleAssignment(u.roleRepo, principal, uint(user.RoleID)); err != nil {
+			return nil, err
+		}
+	}
+
 	// Check if username already exists
-	exists, err := u.userRepo.ExistsByUsername(user.Username)
+	exists, err := u.userRepo.ExistsByUsername(ctx, user.Username)
 	if err != nil {
 		return nil, err
 	}
 	if exists {
-		return nil, errors.New("username already exists")
+		return nil, ErrUsernameExists
 	}
 
 	// Check if email already exists (if email is provided)
 	if user.Email != nil && *user.Email != "" {
-		emailExists, err := u.userRepo.ExistsByEmail(*user.Email)
+		emailExists, err := u.userRepo.ExistsByEmail(ctx, *user.Email)
 		if err != nil {
 			return nil, err
 		}
 		if emailExists {
-			return nil, errors.New("email already exists")
+			return nil, ErrEmailExists
 		}
 	}
 
+	if err := u.policy.Check(ctx, user, user.Password); err != nil {
+		return nil, err
+	}
+
 	// Hash password
-	hashedPassword, err := infrastructure.HashPassword(user.Password)
+	hashedPassword, err := u.hasher.Hash(ctx, user.Password)
 	if err != nil {
 		return nil, err
 	}
-	user.Password = string(hashedPassword)
+	user.Password = hashedPassword
+	user.Status = entity.UserStatusActive
 
 	// Create user
-	if err := u.userRepo.Create(user); err != nil {
-		return nil, err
+	if err := u.userRepo.Create(ctx, user); err != nil {
+		return nil, uniquenessError(err)
 	}
+	u.sendVerification(user)
 
 	return user, nil
 }
 
-func (u *UserUseCase) UpdateUser(token string, userID int, updateData *entity.User) (*entity.User, error) {
-	// Validate token
-	_, err := infrastructure.ValidateToken(token)
+// CreateBootstrapUser creates the account of the bootstrap admin, unless
+// the username is taken. The account has the default role until
+// RoleUseCase.BootstrapAdmin grants it the admin role.
+func (u *UserUseCase) CreateBootstrapUser(ctx context.Context, username, password string) error {
+	exists, err := u.userRepo.ExistsByUsername(ctx, username)
+	if err != nil || exists {
+		return err
+	}
+
+	user := &entity.User{Username: username, Name: username, RoleID: entity.DefaultRoleID}
+	if err := u.policy.Check(ctx, user, password); err != nil {
+		return err
+	}
+	hashedPassword, err := u.hasher.Hash(ctx, password)
+	if err != nil {
+		return err
+	}
+	user.Password = hashedPassword
+	user.Status = entity.UserStatusActive
+
+	// Another replica starting at the same time may have created it
+	if err := uniquenessError(u.userRepo.Create(ctx, user)); err != nil && !errors.Is(err, ErrUsernameExists) {
+		return err
+	}
+	return nil
+}
+
+func (u *UserUseCase) UpdateUser(ctx context.Context, userID int, updateData *entity.User) (*entity.User, error) {
+	// Users may always edit their own profile fields
+	principal, err := requireSelfOrPermission(ctx, uint(userID), entity.PermissionUsersUpdate)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get existing user
-	existingUser, err := u.userRepo.FindByID(userID)
+	existingUser, err := u.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Check if username already exists (excluding current user)
-	if updateData.Username != existingUser.Username {
-		exists, err := u.userRepo.ExistsByUsernameExcludeID(updateData.Username, existingUser.ID)
-		if err != nil {
+	// A role of 0 means "unchanged"; any other change is a role assignment
+	if updateData.RoleID != 0 && updateData.RoleID != existingUser.RoleID {
+		if err := checkRoleAssignment(u.roleRepo, principal, uint(updateData.RoleID)); err != nil {
 			return nil, err
 		}
-		if exists {
-			return nil, errors.New("username already exists")
-		}
+		existingUser.RoleID = updateData.RoleID
 	}
 
-	// Check if email already exists (excluding current user)
-	if updateData.Email != nil && *updateData.Email != "" {
-		// Only check if email is different from current
-		currentEmail := ""
-		if existingUser.Email != nil {
-			currentEmail = *existingUser.Email
-		}
+	// A new address has to be verified again
+	emailChanged := deref(updateData.Email) != deref(existingUser.Email)
+	if emailChanged {
+		existingUser.EmailVerifiedAt = nil
+	}
 
-		if *updateData.Email != currentEmail {
-			emailExists, err := u.userRepo.ExistsByEmailExcludeID(*updateData.Email, existingUser.ID)
-			if err != nil {
-				return nil, err
-			}
-			if emailExists {
-				return nil, errors.New("email already exists")
-			}
+	// Accounts left without a normalized username by a collision keep it
+	// that way until they are renamed
+	if updateData.Username != existingUser.Username {
+		username := entity.NormalizeUsername(updateData.Username)
+		existingUser.UsernameNormalized = &username
+	}
+	if emailChanged {
+		existingUser.EmailNormalized = nil
+		if deref(updateData.Email) != "" {
+			email := entity.NormalizeEmail(*updateData.Email)
+			existingUser.EmailNormalized = &email
 		}
 	}
 
@@ -233,30 +939,99 @@ func (u *UserUseCase) UpdateUser(token string, userID int, updateData *entity.Us
 	existingUser.Phone = updateData.Phone
 	existingUser.Mobile = updateData.Mobile
 	existingUser.ImageURL = updateData.ImageURL
-	existingUser.IsActive = updateData.IsActive
-	existingUser.RoleID = updateData.RoleID
 
 	// Update user
-	if err := u.userRepo.Update(existingUser); err != nil {
-		return nil, err
+	if err := u.userRepo.Update(ctx, existingUser); err != nil {
+		return nil, uniquenessError(err)
+	}
+	if emailChanged {
+		u.sendVerification(existingUser)
 	}
 
 	return existingUser, nil
 }
 
-func (u *UserUseCase) DeleteUser(token string, userID int) error {
-	// Validate token
-	_, err := infrastructure.ValidateToken(token)
+func (u *UserUseCase) DeleteUser(ctx context.Context, userID int) error {
+	_, err := requirePermission(ctx, entity.PermissionUsersDelete)
 	if err != nil {
 		return err
 	}
 
 	// Check if user exists
-	_, err = u.userRepo.FindByID(userID)
+	_, err = u.userRepo.FindByID(ctx, userID)
 	if err != nil {
 		return err
 	}
 
 	// Delete user
-	return u.userRepo.Delete(userID)
+	return u.userRepo.Delete(ctx, userID)
+}
+
+// checkRoleAssignment verifies the caller may assign roles and the role exists.
+func checkRoleAssignment(roleRepo repository.RoleRepository, principal *Principal, roleID uint) error {
+	if !principal.HasPermission(entity.PermissionRolesAssign) {
+		return ErrPermissionDenied
+	}
+	if _, err := roleRepo.FindByID(roleID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrRoleNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// rehashPassword upgrades a stored hash made with an outdated algorithm or
+// parameters, now that the plain password is at hand. Failing to do so is
+// harmless, the old hash keeps working until the next login.
+func (u *UserUseCase) rehashPassword(ctx context.Context, user *entity.User, password string) {
+	if !u.hasher.NeedsRehash(user.Password) {
+		return
+	}
+	hashedPassword, err := u.hasher.Hash(ctx, password)
+	if err == nil {
+		err = u.userRepo.UpdatePassword(ctx, user.ID, hashedPassword)
+	}
+	if err != nil {
+		log.Printf("Failed to upgrade password hash of user %d: %v", user.ID, err)
+	}
+}
+
+// releaseAttempt hands back a login attempt that could not be checked, such
+// as when the server was too busy to compare the password. Failing to do so
+// only counts the attempt as failed.
+func (u *UserUseCase) releaseAttempt(username, clientIP string) {
+	if err := u.loginGuard.Release(username, clientIP); err != nil {
+		log.Printf("Failed to release login attempt of %q: %v", username, err)
+	}
+}
+
+// sendVerification starts email verification for a user that was just saved.
+// A failure only delays verification, as the user can ask for a new token;
+// tokens left valid by a failed invalidation only verify the address they
+// were sent to.
+func (u *UserUseCase) sendVerification(user *entity.User) {
+	if err := u.verification.SendVerification(user); err != nil {
+		log.Printf("Failed to start email verification for user %d: %v", user.ID, err)
+	}
+}
+
+func deref(s *string) string {
+	if s != nil {
+		return *s
+	}
+	return ""
+}
+
+// uniquenessError returns the repository's duplicate errors as
+// ErrUsernameExists and ErrEmailExists. The unique indexes decide rather than
+// a lookup beforehand, which concurrent requests could both pass.
+func uniquenessError(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrDuplicateUsername):
+		return ErrUsernameExists
+	case errors.Is(err, repository.ErrDuplicateEmail):
+		return ErrEmailExists
+	}
+	return err
 }